@@ -0,0 +1,360 @@
+// Mcpdump prints the MCP frames contained in hex strings, raw binary files
+// or pcap and pcapng captures.
+//
+// Usage:
+//
+//	mcpdump [-format=auto|hex|raw|pcap] [-dir=c2s|s2c] [-id=list] [-json] [-x] [file ...]
+//	mcpdump -hex="07 00 07 00 00 00 00" [-dir=s2c]
+//
+// Each frame is printed on one line holding the capture time and
+// endpoints (for captures), the direction, the message ID and the decoded
+// fields. With -json each frame is printed as a JSON object instead,
+// which mcp.JSONToFrame accepts. Files named "-" and the absence of files
+// read standard input.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/capture"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/dissect"
+)
+
+var (
+	format    = flag.String("format", "auto", "input format: auto, hex, raw or pcap")
+	dirName   = flag.String("dir", "c2s", "direction of hex and raw input: c2s or s2c")
+	ids       = flag.String("id", "", "comma-separated list of message IDs to print, by name or number")
+	jsonLines = flag.Bool("json", false, "print JSON lines")
+	dump      = flag.Bool("x", false, "print an annotated hexdump after each frame")
+	hexInput  = flag.String("hex", "", "decode the frames in this hex string instead of reading files")
+)
+
+// pcapMagic holds the leading bytes of the capture formats read by the
+// capture package
+var pcapMagic = [][]byte{
+	{0xd4, 0xc3, 0xb2, 0xa1}, {0xa1, 0xb2, 0xc3, 0xd4},
+	{0x4d, 0x3c, 0xb2, 0xa1}, {0xa1, 0xb2, 0x3c, 0x4d},
+	{0x0a, 0x0d, 0x0d, 0x0a},
+}
+
+// record is a frame to be printed
+type record struct {
+	time           time.Time
+	client, server string
+	dir            mcp.Direction
+	frame          []byte
+	msg            mcp.Message
+	err            error
+}
+
+type printer struct {
+	w      *bufio.Writer
+	filter map[mcp.MessageID]bool
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("mcpdump: ")
+	flag.Parse()
+
+	dir, err := parseDirection(*dirName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	filter, err := parseIDs(*ids)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	p := &printer{w: bufio.NewWriter(os.Stdout), filter: filter}
+	defer p.w.Flush()
+
+	if *hexInput != "" {
+		if err := p.input("-hex", []byte(*hexInput), "hex", dir); err != nil {
+			p.w.Flush()
+			log.Fatal(err)
+		}
+		return
+	}
+
+	files := flag.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	failed := false
+	for _, name := range files {
+		data, err := readFile(name)
+		if err == nil {
+			err = p.input(name, data, *format, dir)
+		}
+		if err != nil {
+			p.w.Flush()
+			log.Printf("%s: %s", name, err)
+			failed = true
+		}
+	}
+	if failed {
+		p.w.Flush()
+		os.Exit(1)
+	}
+}
+
+func readFile(name string) ([]byte, error) {
+	if name == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+	return ioutil.ReadFile(name)
+}
+
+// input prints the frames in data read from the input called name.
+func (p *printer) input(name string, data []byte, format string, dir mcp.Direction) error {
+	if format == "auto" {
+		format = detectFormat(data)
+	}
+
+	switch format {
+	case "pcap":
+		return p.capture(data)
+	case "hex":
+		b, err := parseHex(data)
+		if err != nil {
+			return err
+		}
+		return p.frames(b, dir)
+	case "raw":
+		return p.frames(data, dir)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// detectFormat guesses the format of data from its content.
+func detectFormat(data []byte) string {
+	for _, m := range pcapMagic {
+		if bytes.HasPrefix(data, m) {
+			return "pcap"
+		}
+	}
+	if b, err := parseHex(data); err == nil && len(b) > 0 {
+		return "hex"
+	}
+	return "raw"
+}
+
+// parseHex decodes hex digits separated by white space or commas. Tokens
+// may carry a 0x prefix and text following a '#' on a line is a comment.
+func parseHex(data []byte) ([]byte, error) {
+	var digits strings.Builder
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		for _, tok := range strings.FieldsFunc(line, isSeparator) {
+			digits.WriteString(strings.TrimPrefix(strings.TrimPrefix(tok, "0x"), "0X"))
+		}
+	}
+	return hex.DecodeString(digits.String())
+}
+
+func isSeparator(r rune) bool {
+	return r == ',' || unicode.IsSpace(r)
+}
+
+// frames prints the concatenated frames in data sent in dir.
+func (p *printer) frames(data []byte, dir mcp.Direction) error {
+	r := mcp.NewReader(bytes.NewReader(data))
+	off := 0
+	for {
+		f, err := r.ReadFrame()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("frame at offset %d: %s", off, err)
+		}
+		n := int(f.Header.Length)
+		rec := &record{dir: dir, frame: data[off : off+n]}
+		rec.msg, rec.err = mcp.DecodeFrame(dir, f)
+		if _, ok := rec.err.(*mcp.UnknownMessageError); ok {
+			rec.err = nil
+		}
+		if rec.msg == nil {
+			rec.msg = &mcp.RawMessage{MessageID: f.Header.MessageID, Dir: dir, Payload: f.Payload}
+		}
+		if err := p.print(rec); err != nil {
+			return err
+		}
+		off += n
+	}
+}
+
+// capture prints the MCP messages in the pcap or pcapng capture data.
+func (p *printer) capture(data []byte) error {
+	r, err := capture.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	for {
+		m, err := r.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		rec := &record{
+			time:   m.Time,
+			client: m.Client.String(),
+			server: m.Server.String(),
+			dir:    m.Direction,
+			msg:    m.Message,
+			err:    m.Err,
+		}
+		if m.Frame != nil {
+			rec.frame = frameBytes(m.Frame)
+		}
+		if err := p.print(rec); err != nil {
+			return err
+		}
+	}
+}
+
+func frameBytes(f *mcp.Frame) []byte {
+	b := make([]byte, mcp.HeaderLength, mcp.HeaderLength+len(f.Payload))
+	binary.LittleEndian.PutUint16(b, f.Header.Length)
+	b[2] = byte(f.Header.MessageID)
+	return append(b, f.Payload...)
+}
+
+func (p *printer) print(rec *record) error {
+	if rec.msg != nil && p.filter != nil && !p.filter[rec.msg.ID()] {
+		return nil
+	}
+	if *jsonLines {
+		return p.printJSON(rec)
+	}
+
+	var line []string
+	if !rec.time.IsZero() {
+		line = append(line, rec.time.Format("2006-01-02T15:04:05.000000Z07:00"))
+		if rec.dir == mcp.ClientToServer {
+			line = append(line, rec.client, ">", rec.server)
+		} else {
+			line = append(line, rec.server, ">", rec.client)
+		}
+	}
+	line = append(line, rec.dir.String())
+
+	var d *dissect.Frame
+	if rec.frame != nil {
+		var err error
+		if d, err = dissect.Dissect(rec.dir, rec.frame); err != nil && rec.err == nil {
+			rec.err = err
+		}
+	}
+	if rec.msg != nil {
+		line = append(line, rec.msg.ID().String())
+	}
+	if d != nil {
+		for _, f := range d.Fields {
+			if strings.HasPrefix(f.Name, "Header.") {
+				continue
+			}
+			line = append(line, f.Name+"="+f.String())
+		}
+	}
+	if rec.err != nil {
+		line = append(line, "error="+strconv.Quote(rec.err.Error()))
+	}
+
+	fmt.Fprintln(p.w, strings.Join(line, " "))
+	if *dump && d != nil {
+		if _, err := d.WriteTo(p.w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *printer) printJSON(rec *record) error {
+	var prefix []string
+	if !rec.time.IsZero() {
+		prefix = append(prefix,
+			`"time":`+strconv.Quote(rec.time.Format(time.RFC3339Nano)),
+			`"client":`+strconv.Quote(rec.client),
+			`"server":`+strconv.Quote(rec.server),
+		)
+	}
+	if rec.err != nil {
+		prefix = append(prefix, `"error":`+strconv.Quote(rec.err.Error()))
+	}
+
+	var body []byte
+	if rec.msg != nil {
+		var err error
+		if body, err = mcp.MarshalJSON(rec.msg); err != nil {
+			return err
+		}
+	} else {
+		body = []byte(`{"dir":` + strconv.Quote(rec.dir.String()) + `}`)
+	}
+
+	if len(prefix) > 0 {
+		p.w.WriteString("{" + strings.Join(prefix, ",") + ",")
+		body = body[1:]
+	}
+	p.w.Write(body)
+	return p.w.WriteByte('\n')
+}
+
+func parseDirection(s string) (mcp.Direction, error) {
+	switch s {
+	case mcp.ClientToServer.String():
+		return mcp.ClientToServer, nil
+	case mcp.ServerToClient.String():
+		return mcp.ServerToClient, nil
+	}
+	return 0, fmt.Errorf("unknown direction %q", s)
+}
+
+// parseIDs parses a comma-separated list of message ID names, such as
+// McpJoinGame, or numbers. It returns nil for an empty list.
+func parseIDs(s string) (map[mcp.MessageID]bool, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	names := make(map[string]mcp.MessageID)
+	for i := 0; i <= 0xff; i++ {
+		names[mcp.MessageID(i).String()] = mcp.MessageID(i)
+	}
+
+	filter := make(map[mcp.MessageID]bool)
+	for _, tok := range strings.Split(s, ",") {
+		tok = strings.TrimSpace(tok)
+		if id, ok := names[tok]; ok {
+			filter[id] = true
+			continue
+		}
+		n, err := strconv.ParseUint(tok, 0, 8)
+		if err != nil {
+			return nil, fmt.Errorf("unknown message ID %q", tok)
+		}
+		filter[mcp.MessageID(n)] = true
+	}
+	return filter, nil
+}
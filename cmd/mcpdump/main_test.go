@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/client"
+)
+
+func TestParseHex(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []byte
+	}{
+		{"", []byte{}},
+		{"0a 00 07", []byte{0x0a, 0x00, 0x07}},
+		{"0a0007", []byte{0x0a, 0x00, 0x07}},
+		{"0x0a, 0x00,0X07", []byte{0x0a, 0x00, 0x07}},
+		{"0a 00  # header\n07\t# CharLogon\n# trailing comment", []byte{0x0a, 0x00, 0x07}},
+		{"\r\n0A\r\n00\r\n", []byte{0x0a, 0x00}},
+	}
+	for _, tt := range tests {
+		got, err := parseHex([]byte(tt.in))
+		if err != nil {
+			t.Errorf("%q: %s", tt.in, err)
+			continue
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("%q: got % x, want % x", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"0a 0", "zz", "0a;00"} {
+		if got, err := parseHex([]byte(in)); err == nil {
+			t.Errorf("%q: got % x, want an error", in, got)
+		}
+	}
+}
+
+func TestParseIDs(t *testing.T) {
+	tests := []struct {
+		in   string
+		want map[mcp.MessageID]bool
+	}{
+		{"", nil},
+		{"McpJoinGame", map[mcp.MessageID]bool{mcp.McpJoinGame: true}},
+		{"4, 0x07,McpMOTD", map[mcp.MessageID]bool{mcp.McpJoinGame: true, mcp.McpCharLogon: true, mcp.McpMOTD: true}},
+		{"0xff", map[mcp.MessageID]bool{0xff: true}},
+	}
+	for _, tt := range tests {
+		got, err := parseIDs(tt.in)
+		if err != nil {
+			t.Errorf("%q: %s", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"JoinGame", "mcpjoingame", "0x100", "-1", "McpMOTD,", "7 8"} {
+		if got, err := parseIDs(in); err == nil {
+			t.Errorf("%q: got %v, want an error", in, got)
+		}
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	for _, m := range pcapMagic {
+		data := append(append([]byte{}, m...), make([]byte, 20)...)
+		if got := detectFormat(data); got != "pcap" {
+			t.Errorf("% x: got %s, want pcap", m, got)
+		}
+	}
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"04 00 07\n", "hex"},
+		{"# MCP_CHARLOGON\n0x04, 0x00, 0x07", "hex"},
+		{"\x04\x00\x07", "raw"},
+		{"0a 00 0", "raw"},
+		{"\n\r\r", "raw"},
+	}
+	for _, tt := range tests {
+		if got := detectFormat([]byte(tt.in)); got != tt.want {
+			t.Errorf("%q: got %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func printJSON(t *testing.T, rec *record) map[string]interface{} {
+	t.Helper()
+	var buf bytes.Buffer
+	p := &printer{w: bufio.NewWriter(&buf)}
+	if err := p.printJSON(rec); err != nil {
+		t.Fatal(err)
+	}
+	p.w.Flush()
+
+	line := buf.Bytes()
+	if bytes.Count(line, []byte("\n")) != 1 || line[len(line)-1] != '\n' {
+		t.Fatalf("not a single line: %q", line)
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal(line, &v); err != nil {
+		t.Fatalf("%s: %s", line, err)
+	}
+	return v
+}
+
+func TestPrintJSON(t *testing.T) {
+	msg := &client.CharLogon{CharacterName: "Conan"}
+	at := time.Date(2019, 4, 26, 12, 0, 0, 500, time.UTC)
+
+	tests := []struct {
+		name string
+		rec  *record
+		want map[string]interface{}
+	}{
+		{
+			name: "message",
+			rec:  &record{dir: mcp.ClientToServer, msg: msg},
+			want: map[string]interface{}{"id": "McpCharLogon", "dir": "c2s"},
+		},
+		{
+			name: "capture",
+			rec: &record{
+				time: at, client: "10.0.0.2:50000", server: "10.0.0.1:6112",
+				dir: mcp.ClientToServer, msg: msg,
+			},
+			want: map[string]interface{}{
+				"time": "2019-04-26T12:00:00.0000005Z", "client": "10.0.0.2:50000", "server": "10.0.0.1:6112",
+				"id": "McpCharLogon", "dir": "c2s",
+			},
+		},
+		{
+			name: "error",
+			rec:  &record{dir: mcp.ServerToClient, err: errors.New("mcp: bad frame")},
+			want: map[string]interface{}{"error": "mcp: bad frame", "dir": "s2c"},
+		},
+		{
+			name: "capture error",
+			rec: &record{
+				time: at, client: "10.0.0.2:50000", server: "10.0.0.1:6112",
+				dir: mcp.ServerToClient, err: errors.New("mcp: bad frame"),
+			},
+			want: map[string]interface{}{
+				"time": "2019-04-26T12:00:00.0000005Z", "client": "10.0.0.2:50000", "server": "10.0.0.1:6112",
+				"error": "mcp: bad frame", "dir": "s2c",
+			},
+		},
+	}
+	for _, tt := range tests {
+		got := printJSON(t, tt.rec)
+		if tt.rec.msg != nil {
+			fields, ok := got["fields"].(map[string]interface{})
+			if !ok || fields["CharacterName"] != "Conan" {
+				t.Errorf("%s: fields %v", tt.name, got["fields"])
+			}
+			delete(got, "fields")
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
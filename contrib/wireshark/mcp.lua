@@ -0,0 +1,703 @@
+-- Code generated by "luagen -output ../../../contrib/wireshark/mcp.lua"; DO NOT EDIT.
+--
+-- Wireshark dissector for the Diablo II realm server protocol (MCP).
+-- Load with "wireshark -X lua_script:mcp.lua" or copy into the plugins
+-- directory.
+
+local mcp = Proto("mcp", "Diablo II Realm Server Protocol (MCP)")
+mcp.prefs.port = Pref.uint("TCP port", 6112, "TCP port of the realm server")
+
+local HEADER_LENGTH = 3
+local PROTOCOL_SELECTOR = 0x01
+local MCP_STARTUP = 0x01
+
+local vs_mcp_messageid = {
+	[0x01] = "McpStartup",
+	[0x02] = "McpCharCreate",
+	[0x03] = "McpCreateGame",
+	[0x04] = "McpJoinGame",
+	[0x05] = "McpGameList",
+	[0x06] = "McpGameInfo",
+	[0x07] = "McpCharLogon",
+	[0x0a] = "McpCharDelete",
+	[0x11] = "McpRequestLadderData",
+	[0x12] = "McpMOTD",
+	[0x13] = "McpCancelGameCreate",
+	[0x14] = "McpCreateQueue",
+	[0x16] = "McpCharRank",
+	[0x17] = "McpCharList",
+	[0x18] = "McpCharUpgrade",
+	[0x19] = "McpCharList2",
+}
+
+local vs_mcp_characterclass = {
+	[0x00] = "Amazon",
+	[0x01] = "Sorceress",
+	[0x02] = "Necromancer",
+	[0x03] = "Paladin",
+	[0x04] = "Barbarian",
+	[0x05] = "Druid",
+	[0x06] = "Assassin",
+}
+
+local vs_mcp_difficulty = {
+	[0x00] = "Normal",
+	[0x1000] = "Nightmare",
+	[0x2000] = "Hell",
+}
+
+local vs_server_startupresult = {
+	[0x00] = "StartupSuccess",
+	[0x02] = "StartupNoBattleNetConnection",
+	[0x0a] = "StartupNoBattleNetConnection0A",
+	[0x0b] = "StartupNoBattleNetConnection0B",
+	[0x0c] = "StartupNoBattleNetConnection0C",
+	[0x0d] = "StartupNoBattleNetConnection0D",
+	[0x7e] = "StartupKeyBanned",
+	[0x7f] = "StartupTemporaryBan",
+}
+
+local vs_server_charcreateresult = {
+	[0x00] = "CharCreateSuccess",
+	[0x14] = "CharCreateAlreadyExists",
+	[0x15] = "CharCreateInvalidName",
+}
+
+local vs_server_creategameresult = {
+	[0x00] = "CreateGameSuccess",
+	[0x1e] = "CreateGameInvalidName",
+	[0x1f] = "CreateGameAlreadyExists",
+	[0x20] = "CreateGameServersDown",
+	[0x6e] = "CreateGameDeadHardcore",
+}
+
+local vs_server_joingameresult = {
+	[0x00] = "JoinGameSuccess",
+	[0x29] = "JoinGamePasswordIncorrect",
+	[0x2a] = "JoinGameNotFound",
+	[0x2b] = "JoinGameFull",
+	[0x2c] = "JoinGameLevelRequirement",
+	[0x6e] = "JoinGameDeadHardcore",
+	[0x71] = "JoinGameHardcoreOnly",
+	[0x73] = "JoinGameNightmareLocked",
+	[0x74] = "JoinGameHellLocked",
+	[0x78] = "JoinGameExpansionOnly",
+	[0x79] = "JoinGameClassicOnly",
+	[0x7d] = "JoinGameLadderOnly",
+}
+
+local vs_server_charlogonresult = {
+	[0x00] = "CharLogonSuccess",
+	[0x46] = "CharLogonNotFound",
+	[0x7a] = "CharLogonFailed",
+	[0x7b] = "CharLogonExpired",
+}
+
+local vs_server_chardeleteresult = {
+	[0x00] = "CharDeleteSuccess",
+	[0x49] = "CharDeleteNotFound",
+}
+
+local vs_server_charrankresult = {
+	[0x00] = "CharRankSuccess",
+}
+
+local vs_server_charupgraderesult = {
+	[0x00] = "CharUpgradeSuccess",
+	[0x46] = "CharUpgradeNotFound",
+	[0x7a] = "CharUpgradeFailed",
+	[0x7b] = "CharUpgradeExpired",
+	[0x7c] = "CharUpgradeAlreadyExpansion",
+}
+
+local f = mcp.fields
+f.selector = ProtoField.uint8("mcp.selector", "Protocol selector", base.HEX)
+f.length = ProtoField.uint16("mcp.length", "Length", base.DEC)
+f.id = ProtoField.uint8("mcp.id", "Message ID", base.HEX, vs_mcp_messageid)
+f.payload = ProtoField.bytes("mcp.payload", "Payload")
+f.trailing = ProtoField.bytes("mcp.trailing", "Trailing data")
+f.c2s_startup_mcpcookie = ProtoField.uint32("mcp.c2s.startup.mcpcookie", "MCPCookie", base.DEC)
+f.c2s_startup_mcpstatus = ProtoField.uint32("mcp.c2s.startup.mcpstatus", "MCPStatus", base.DEC)
+f.c2s_startup_chunk1 = ProtoField.uint32("mcp.c2s.startup.chunk1", "Chunk1", base.DEC)
+f.c2s_startup_chunk2 = ProtoField.uint32("mcp.c2s.startup.chunk2", "Chunk2", base.DEC)
+f.c2s_startup_uniquename = ProtoField.stringz("mcp.c2s.startup.uniquename", "UniqueName")
+f.c2s_charcreate_class = ProtoField.uint32("mcp.c2s.charcreate.class", "Class", base.HEX, vs_mcp_characterclass)
+f.c2s_charcreate_flags = ProtoField.uint16("mcp.c2s.charcreate.flags", "Flags", base.DEC)
+f.c2s_charcreate_name = ProtoField.stringz("mcp.c2s.charcreate.name", "Name")
+f.c2s_creategame_requestid = ProtoField.uint16("mcp.c2s.creategame.requestid", "RequestID", base.DEC)
+f.c2s_creategame_difficulty = ProtoField.uint32("mcp.c2s.creategame.difficulty", "Difficulty", base.HEX, vs_mcp_difficulty)
+f.c2s_creategame_unknown = ProtoField.uint8("mcp.c2s.creategame.unknown", "Unknown", base.DEC)
+f.c2s_creategame_levelrestriction = ProtoField.uint8("mcp.c2s.creategame.levelrestriction", "LevelRestriction", base.DEC)
+f.c2s_creategame_maxplayers = ProtoField.uint8("mcp.c2s.creategame.maxplayers", "MaxPlayers", base.DEC)
+f.c2s_creategame_name = ProtoField.stringz("mcp.c2s.creategame.name", "Name")
+f.c2s_creategame_password = ProtoField.stringz("mcp.c2s.creategame.password", "Password")
+f.c2s_creategame_description = ProtoField.stringz("mcp.c2s.creategame.description", "Description")
+f.c2s_joingame_requestid = ProtoField.uint16("mcp.c2s.joingame.requestid", "RequestID", base.DEC)
+f.c2s_joingame_name = ProtoField.stringz("mcp.c2s.joingame.name", "Name")
+f.c2s_joingame_password = ProtoField.stringz("mcp.c2s.joingame.password", "Password")
+f.c2s_gamelist_requestid = ProtoField.uint16("mcp.c2s.gamelist.requestid", "RequestID", base.DEC)
+f.c2s_gamelist_unknown = ProtoField.uint32("mcp.c2s.gamelist.unknown", "Unknown", base.DEC)
+f.c2s_gamelist_search = ProtoField.stringz("mcp.c2s.gamelist.search", "Search")
+f.c2s_gameinfo_requestid = ProtoField.uint16("mcp.c2s.gameinfo.requestid", "RequestID", base.DEC)
+f.c2s_gameinfo_name = ProtoField.stringz("mcp.c2s.gameinfo.name", "Name")
+f.c2s_charlogon_charactername = ProtoField.stringz("mcp.c2s.charlogon.charactername", "CharacterName")
+f.c2s_chardelete_unknown = ProtoField.uint16("mcp.c2s.chardelete.unknown", "Unknown", base.DEC)
+f.c2s_chardelete_charactername = ProtoField.stringz("mcp.c2s.chardelete.charactername", "CharacterName")
+f.c2s_requestladderdata_laddertype = ProtoField.uint8("mcp.c2s.requestladderdata.laddertype", "LadderType", base.DEC)
+f.c2s_requestladderdata_startingposition = ProtoField.uint16("mcp.c2s.requestladderdata.startingposition", "StartingPosition", base.DEC)
+f.c2s_charrank_hardcore = ProtoField.bool("mcp.c2s.charrank.hardcore", "Hardcore", base.NONE)
+f.c2s_charrank_expansion = ProtoField.bool("mcp.c2s.charrank.expansion", "Expansion", base.NONE)
+f.c2s_charrank_class = ProtoField.uint32("mcp.c2s.charrank.class", "Class", base.HEX, vs_mcp_characterclass)
+f.c2s_charrank_charactername = ProtoField.stringz("mcp.c2s.charrank.charactername", "CharacterName")
+f.c2s_charlist_requestcount = ProtoField.uint32("mcp.c2s.charlist.requestcount", "RequestCount", base.DEC)
+f.c2s_charupgrade_charactername = ProtoField.stringz("mcp.c2s.charupgrade.charactername", "CharacterName")
+f.c2s_charlist2_requestcount = ProtoField.uint32("mcp.c2s.charlist2.requestcount", "RequestCount", base.DEC)
+f.s2c_startup_result = ProtoField.uint32("mcp.s2c.startup.result", "Result", base.HEX, vs_server_startupresult)
+f.s2c_charcreate_result = ProtoField.uint32("mcp.s2c.charcreate.result", "Result", base.HEX, vs_server_charcreateresult)
+f.s2c_creategame_requestid = ProtoField.uint16("mcp.s2c.creategame.requestid", "RequestID", base.DEC)
+f.s2c_creategame_gametoken = ProtoField.uint16("mcp.s2c.creategame.gametoken", "GameToken", base.DEC)
+f.s2c_creategame_unknown = ProtoField.uint16("mcp.s2c.creategame.unknown", "Unknown", base.DEC)
+f.s2c_creategame_result = ProtoField.uint32("mcp.s2c.creategame.result", "Result", base.HEX, vs_server_creategameresult)
+f.s2c_joingame_requestid = ProtoField.uint16("mcp.s2c.joingame.requestid", "RequestID", base.DEC)
+f.s2c_joingame_gametoken = ProtoField.uint16("mcp.s2c.joingame.gametoken", "GameToken", base.DEC)
+f.s2c_joingame_unknown = ProtoField.uint16("mcp.s2c.joingame.unknown", "Unknown", base.DEC)
+f.s2c_joingame_gameserverip = ProtoField.ipv4("mcp.s2c.joingame.gameserverip", "GameServerIP")
+f.s2c_joingame_gamehash = ProtoField.uint32("mcp.s2c.joingame.gamehash", "GameHash", base.DEC)
+f.s2c_joingame_result = ProtoField.uint32("mcp.s2c.joingame.result", "Result", base.HEX, vs_server_joingameresult)
+f.s2c_gamelist_requestid = ProtoField.uint16("mcp.s2c.gamelist.requestid", "RequestID", base.DEC)
+f.s2c_gamelist_index = ProtoField.uint32("mcp.s2c.gamelist.index", "Index", base.DEC)
+f.s2c_gamelist_playercount = ProtoField.uint8("mcp.s2c.gamelist.playercount", "PlayerCount", base.DEC)
+f.s2c_gamelist_status = ProtoField.uint32("mcp.s2c.gamelist.status", "Status", base.DEC)
+f.s2c_gamelist_name = ProtoField.stringz("mcp.s2c.gamelist.name", "Name")
+f.s2c_gamelist_description = ProtoField.stringz("mcp.s2c.gamelist.description", "Description")
+f.s2c_gameinfo_requestid = ProtoField.uint16("mcp.s2c.gameinfo.requestid", "RequestID", base.DEC)
+f.s2c_gameinfo_status = ProtoField.uint32("mcp.s2c.gameinfo.status", "Status", base.DEC)
+f.s2c_gameinfo_uptime = ProtoField.uint32("mcp.s2c.gameinfo.uptime", "Uptime", base.DEC)
+f.s2c_gameinfo_levelrestrictionlevel = ProtoField.uint8("mcp.s2c.gameinfo.levelrestrictionlevel", "LevelRestrictionLevel", base.DEC)
+f.s2c_gameinfo_levelrestrictiondifference = ProtoField.uint8("mcp.s2c.gameinfo.levelrestrictiondifference", "LevelRestrictionDifference", base.DEC)
+f.s2c_gameinfo_maxplayers = ProtoField.uint8("mcp.s2c.gameinfo.maxplayers", "MaxPlayers", base.DEC)
+f.s2c_gameinfo_charactercount = ProtoField.uint8("mcp.s2c.gameinfo.charactercount", "CharacterCount", base.DEC)
+f.s2c_gameinfo_characterclasses = ProtoField.bytes("mcp.s2c.gameinfo.characterclasses", "CharacterClasses")
+f.s2c_gameinfo_characterlevels = ProtoField.bytes("mcp.s2c.gameinfo.characterlevels", "CharacterLevels")
+f.s2c_gameinfo_description = ProtoField.stringz("mcp.s2c.gameinfo.description", "Description")
+f.s2c_gameinfo_characternames = ProtoField.stringz("mcp.s2c.gameinfo.characternames", "CharacterNames")
+f.s2c_charlogon_result = ProtoField.uint32("mcp.s2c.charlogon.result", "Result", base.HEX, vs_server_charlogonresult)
+f.s2c_chardelete_result = ProtoField.uint32("mcp.s2c.chardelete.result", "Result", base.HEX, vs_server_chardeleteresult)
+f.s2c_requestladderdata_laddertype = ProtoField.uint8("mcp.s2c.requestladderdata.laddertype", "LadderType", base.DEC)
+f.s2c_requestladderdata_totalsize = ProtoField.uint16("mcp.s2c.requestladderdata.totalsize", "TotalSize", base.DEC)
+f.s2c_requestladderdata_chunksize = ProtoField.uint16("mcp.s2c.requestladderdata.chunksize", "ChunkSize", base.DEC)
+f.s2c_requestladderdata_remainingsize = ProtoField.uint16("mcp.s2c.requestladderdata.remainingsize", "RemainingSize", base.DEC)
+f.s2c_requestladderdata_firstrank = ProtoField.uint16("mcp.s2c.requestladderdata.firstrank", "FirstRank", base.DEC)
+f.s2c_requestladderdata_unknown = ProtoField.uint16("mcp.s2c.requestladderdata.unknown", "Unknown", base.DEC)
+f.s2c_requestladderdata_data = ProtoField.bytes("mcp.s2c.requestladderdata.data", "Data")
+f.s2c_motd_unknown = ProtoField.uint8("mcp.s2c.motd.unknown", "Unknown", base.DEC)
+f.s2c_motd_message = ProtoField.stringz("mcp.s2c.motd.message", "Message")
+f.s2c_createqueue_position = ProtoField.uint32("mcp.s2c.createqueue.position", "Position", base.DEC)
+f.s2c_charrank_result = ProtoField.uint32("mcp.s2c.charrank.result", "Result", base.HEX, vs_server_charrankresult)
+f.s2c_charrank_rank = ProtoField.uint32("mcp.s2c.charrank.rank", "Rank", base.DEC)
+f.s2c_charlist_requestcount = ProtoField.uint16("mcp.s2c.charlist.requestcount", "RequestCount", base.DEC)
+f.s2c_charlist_existcount = ProtoField.uint32("mcp.s2c.charlist.existcount", "ExistCount", base.DEC)
+f.s2c_charlist_returnedcount = ProtoField.uint16("mcp.s2c.charlist.returnedcount", "ReturnedCount", base.DEC)
+f.s2c_charlistcharacter_name = ProtoField.stringz("mcp.s2c.charlistcharacter.name", "Name")
+f.s2c_charlistcharacter_statstring = ProtoField.stringz("mcp.s2c.charlistcharacter.statstring", "Statstring")
+f.s2c_charupgrade_result = ProtoField.uint32("mcp.s2c.charupgrade.result", "Result", base.HEX, vs_server_charupgraderesult)
+f.s2c_charlist2_requestcount = ProtoField.uint16("mcp.s2c.charlist2.requestcount", "RequestCount", base.DEC)
+f.s2c_charlist2_existcount = ProtoField.uint32("mcp.s2c.charlist2.existcount", "ExistCount", base.DEC)
+f.s2c_charlist2_returnedcount = ProtoField.uint16("mcp.s2c.charlist2.returnedcount", "ReturnedCount", base.DEC)
+f.s2c_charlist2character_expirationdate = ProtoField.uint32("mcp.s2c.charlist2character.expirationdate", "ExpirationDate", base.DEC)
+f.s2c_charlist2character_name = ProtoField.stringz("mcp.s2c.charlist2character.name", "Name")
+f.s2c_charlist2character_statstring = ProtoField.stringz("mcp.s2c.charlist2character.statstring", "Statstring")
+
+-- stringz_length returns the length of the NUL terminated string at off,
+-- including the terminator. Unterminated strings are an error.
+local function stringz_length(buf, off)
+	for i = off, buf:len() - 1 do
+		if buf(i, 1):uint() == 0 then
+			return i - off + 1
+		end
+	end
+	error("unterminated string at offset " .. off)
+end
+
+-- add_stringz adds the NUL terminated string at off to tree as field and
+-- returns the offset following it.
+local function add_stringz(tree, field, buf, off)
+	local n = stringz_length(buf, off)
+	tree:add(field, buf(off, n))
+	return off + n
+end
+
+-- dissect_c2s_startup dissects a c2s Startup
+local function dissect_c2s_startup(buf, tree, off)
+	tree:add_le(f.c2s_startup_mcpcookie, buf(off, 4))
+	off = off + 4
+	tree:add_le(f.c2s_startup_mcpstatus, buf(off, 4))
+	off = off + 4
+	for i = 0, 1 do
+		tree:add_le(f.c2s_startup_chunk1, buf(off, 4))
+		off = off + 4
+	end
+	for i = 0, 11 do
+		tree:add_le(f.c2s_startup_chunk2, buf(off, 4))
+		off = off + 4
+	end
+	off = add_stringz(tree, f.c2s_startup_uniquename, buf, off)
+	return off
+end
+
+-- dissect_c2s_charcreate dissects a c2s CharCreate
+local function dissect_c2s_charcreate(buf, tree, off)
+	tree:add_le(f.c2s_charcreate_class, buf(off, 4))
+	off = off + 4
+	tree:add_le(f.c2s_charcreate_flags, buf(off, 2))
+	off = off + 2
+	off = add_stringz(tree, f.c2s_charcreate_name, buf, off)
+	return off
+end
+
+-- dissect_c2s_creategame dissects a c2s CreateGame
+local function dissect_c2s_creategame(buf, tree, off)
+	tree:add_le(f.c2s_creategame_requestid, buf(off, 2))
+	off = off + 2
+	tree:add_le(f.c2s_creategame_difficulty, buf(off, 4))
+	off = off + 4
+	tree:add_le(f.c2s_creategame_unknown, buf(off, 1))
+	off = off + 1
+	tree:add_le(f.c2s_creategame_levelrestriction, buf(off, 1))
+	off = off + 1
+	tree:add_le(f.c2s_creategame_maxplayers, buf(off, 1))
+	off = off + 1
+	off = add_stringz(tree, f.c2s_creategame_name, buf, off)
+	off = add_stringz(tree, f.c2s_creategame_password, buf, off)
+	off = add_stringz(tree, f.c2s_creategame_description, buf, off)
+	return off
+end
+
+-- dissect_c2s_joingame dissects a c2s JoinGame
+local function dissect_c2s_joingame(buf, tree, off)
+	tree:add_le(f.c2s_joingame_requestid, buf(off, 2))
+	off = off + 2
+	off = add_stringz(tree, f.c2s_joingame_name, buf, off)
+	off = add_stringz(tree, f.c2s_joingame_password, buf, off)
+	return off
+end
+
+-- dissect_c2s_gamelist dissects a c2s GameList
+local function dissect_c2s_gamelist(buf, tree, off)
+	tree:add_le(f.c2s_gamelist_requestid, buf(off, 2))
+	off = off + 2
+	tree:add_le(f.c2s_gamelist_unknown, buf(off, 4))
+	off = off + 4
+	off = add_stringz(tree, f.c2s_gamelist_search, buf, off)
+	return off
+end
+
+-- dissect_c2s_gameinfo dissects a c2s GameInfo
+local function dissect_c2s_gameinfo(buf, tree, off)
+	tree:add_le(f.c2s_gameinfo_requestid, buf(off, 2))
+	off = off + 2
+	off = add_stringz(tree, f.c2s_gameinfo_name, buf, off)
+	return off
+end
+
+-- dissect_c2s_charlogon dissects a c2s CharLogon
+local function dissect_c2s_charlogon(buf, tree, off)
+	off = add_stringz(tree, f.c2s_charlogon_charactername, buf, off)
+	return off
+end
+
+-- dissect_c2s_chardelete dissects a c2s CharDelete
+local function dissect_c2s_chardelete(buf, tree, off)
+	tree:add_le(f.c2s_chardelete_unknown, buf(off, 2))
+	off = off + 2
+	off = add_stringz(tree, f.c2s_chardelete_charactername, buf, off)
+	return off
+end
+
+-- dissect_c2s_requestladderdata dissects a c2s RequestLadderData
+local function dissect_c2s_requestladderdata(buf, tree, off)
+	tree:add_le(f.c2s_requestladderdata_laddertype, buf(off, 1))
+	off = off + 1
+	tree:add_le(f.c2s_requestladderdata_startingposition, buf(off, 2))
+	off = off + 2
+	return off
+end
+
+-- dissect_c2s_motd dissects a c2s MOTD
+local function dissect_c2s_motd(buf, tree, off)
+	return off
+end
+
+-- dissect_c2s_cancelcreategame dissects a c2s CancelCreateGame
+local function dissect_c2s_cancelcreategame(buf, tree, off)
+	return off
+end
+
+-- dissect_c2s_charrank dissects a c2s CharRank
+local function dissect_c2s_charrank(buf, tree, off)
+	tree:add_le(f.c2s_charrank_hardcore, buf(off, 4))
+	off = off + 4
+	tree:add_le(f.c2s_charrank_expansion, buf(off, 4))
+	off = off + 4
+	tree:add_le(f.c2s_charrank_class, buf(off, 4))
+	off = off + 4
+	off = add_stringz(tree, f.c2s_charrank_charactername, buf, off)
+	return off
+end
+
+-- dissect_c2s_charlist dissects a c2s CharList
+local function dissect_c2s_charlist(buf, tree, off)
+	tree:add_le(f.c2s_charlist_requestcount, buf(off, 4))
+	off = off + 4
+	return off
+end
+
+-- dissect_c2s_charupgrade dissects a c2s CharUpgrade
+local function dissect_c2s_charupgrade(buf, tree, off)
+	off = add_stringz(tree, f.c2s_charupgrade_charactername, buf, off)
+	return off
+end
+
+-- dissect_c2s_charlist2 dissects a c2s CharList2
+local function dissect_c2s_charlist2(buf, tree, off)
+	tree:add_le(f.c2s_charlist2_requestcount, buf(off, 4))
+	off = off + 4
+	return off
+end
+
+-- dissect_s2c_startup dissects a s2c Startup
+local function dissect_s2c_startup(buf, tree, off)
+	tree:add_le(f.s2c_startup_result, buf(off, 4))
+	off = off + 4
+	return off
+end
+
+-- dissect_s2c_charcreate dissects a s2c CharCreate
+local function dissect_s2c_charcreate(buf, tree, off)
+	tree:add_le(f.s2c_charcreate_result, buf(off, 4))
+	off = off + 4
+	return off
+end
+
+-- dissect_s2c_creategame dissects a s2c CreateGame
+local function dissect_s2c_creategame(buf, tree, off)
+	tree:add_le(f.s2c_creategame_requestid, buf(off, 2))
+	off = off + 2
+	tree:add_le(f.s2c_creategame_gametoken, buf(off, 2))
+	off = off + 2
+	tree:add_le(f.s2c_creategame_unknown, buf(off, 2))
+	off = off + 2
+	tree:add_le(f.s2c_creategame_result, buf(off, 4))
+	off = off + 4
+	return off
+end
+
+-- dissect_s2c_joingame dissects a s2c JoinGame
+local function dissect_s2c_joingame(buf, tree, off)
+	tree:add_le(f.s2c_joingame_requestid, buf(off, 2))
+	off = off + 2
+	tree:add_le(f.s2c_joingame_gametoken, buf(off, 2))
+	off = off + 2
+	tree:add_le(f.s2c_joingame_unknown, buf(off, 2))
+	off = off + 2
+	tree:add(f.s2c_joingame_gameserverip, buf(off, 4))
+	off = off + 4
+	tree:add_le(f.s2c_joingame_gamehash, buf(off, 4))
+	off = off + 4
+	tree:add_le(f.s2c_joingame_result, buf(off, 4))
+	off = off + 4
+	return off
+end
+
+-- dissect_s2c_gamelist dissects a s2c GameList
+local function dissect_s2c_gamelist(buf, tree, off)
+	tree:add_le(f.s2c_gamelist_requestid, buf(off, 2))
+	off = off + 2
+	tree:add_le(f.s2c_gamelist_index, buf(off, 4))
+	off = off + 4
+	tree:add_le(f.s2c_gamelist_playercount, buf(off, 1))
+	off = off + 1
+	tree:add_le(f.s2c_gamelist_status, buf(off, 4))
+	off = off + 4
+	off = add_stringz(tree, f.s2c_gamelist_name, buf, off)
+	off = add_stringz(tree, f.s2c_gamelist_description, buf, off)
+	return off
+end
+
+-- dissect_s2c_gameinfo dissects a s2c GameInfo
+local function dissect_s2c_gameinfo(buf, tree, off)
+	tree:add_le(f.s2c_gameinfo_requestid, buf(off, 2))
+	off = off + 2
+	tree:add_le(f.s2c_gameinfo_status, buf(off, 4))
+	off = off + 4
+	tree:add_le(f.s2c_gameinfo_uptime, buf(off, 4))
+	off = off + 4
+	tree:add_le(f.s2c_gameinfo_levelrestrictionlevel, buf(off, 1))
+	off = off + 1
+	tree:add_le(f.s2c_gameinfo_levelrestrictiondifference, buf(off, 1))
+	off = off + 1
+	tree:add_le(f.s2c_gameinfo_maxplayers, buf(off, 1))
+	off = off + 1
+	local save_CharacterCount = buf(off, 1):le_uint()
+	tree:add_le(f.s2c_gameinfo_charactercount, buf(off, 1))
+	off = off + 1
+	tree:add(f.s2c_gameinfo_characterclasses, buf(off, 16))
+	off = off + 16
+	tree:add(f.s2c_gameinfo_characterlevels, buf(off, 16))
+	off = off + 16
+	off = add_stringz(tree, f.s2c_gameinfo_description, buf, off)
+	if save_CharacterCount == 0 then
+		-- An empty list is sent as a single empty string
+		if off < buf:len() and buf(off, 1):uint() == 0 then
+			tree:add(f.s2c_gameinfo_characternames, buf(off, 1))
+			off = off + 1
+		end
+	else
+		for i = 1, save_CharacterCount do
+			off = add_stringz(tree, f.s2c_gameinfo_characternames, buf, off)
+		end
+	end
+	return off
+end
+
+-- dissect_s2c_charlogon dissects a s2c CharLogon
+local function dissect_s2c_charlogon(buf, tree, off)
+	tree:add_le(f.s2c_charlogon_result, buf(off, 4))
+	off = off + 4
+	return off
+end
+
+-- dissect_s2c_chardelete dissects a s2c CharDelete
+local function dissect_s2c_chardelete(buf, tree, off)
+	tree:add_le(f.s2c_chardelete_result, buf(off, 4))
+	off = off + 4
+	return off
+end
+
+-- dissect_s2c_requestladderdata dissects a s2c RequestLadderData
+local function dissect_s2c_requestladderdata(buf, tree, off)
+	tree:add_le(f.s2c_requestladderdata_laddertype, buf(off, 1))
+	off = off + 1
+	tree:add_le(f.s2c_requestladderdata_totalsize, buf(off, 2))
+	off = off + 2
+	local save_RLDChunk = buf(off, 2):le_uint()
+	tree:add_le(f.s2c_requestladderdata_chunksize, buf(off, 2))
+	off = off + 2
+	tree:add_le(f.s2c_requestladderdata_remainingsize, buf(off, 2))
+	off = off + 2
+	tree:add_le(f.s2c_requestladderdata_firstrank, buf(off, 2))
+	off = off + 2
+	tree:add_le(f.s2c_requestladderdata_unknown, buf(off, 2))
+	off = off + 2
+	if save_RLDChunk > 0 then
+		tree:add(f.s2c_requestladderdata_data, buf(off, save_RLDChunk))
+		off = off + save_RLDChunk
+	end
+	return off
+end
+
+-- dissect_s2c_motd dissects a s2c MOTD
+local function dissect_s2c_motd(buf, tree, off)
+	tree:add_le(f.s2c_motd_unknown, buf(off, 1))
+	off = off + 1
+	off = add_stringz(tree, f.s2c_motd_message, buf, off)
+	return off
+end
+
+-- dissect_s2c_createqueue dissects a s2c CreateQueue
+local function dissect_s2c_createqueue(buf, tree, off)
+	tree:add_le(f.s2c_createqueue_position, buf(off, 4))
+	off = off + 4
+	return off
+end
+
+-- dissect_s2c_charrank dissects a s2c CharRank
+local function dissect_s2c_charrank(buf, tree, off)
+	tree:add_le(f.s2c_charrank_result, buf(off, 4))
+	off = off + 4
+	tree:add_le(f.s2c_charrank_rank, buf(off, 4))
+	off = off + 4
+	return off
+end
+
+-- dissect_s2c_charlistcharacter dissects a s2c CharListCharacter
+local function dissect_s2c_charlistcharacter(buf, tree, off)
+	off = add_stringz(tree, f.s2c_charlistcharacter_name, buf, off)
+	off = add_stringz(tree, f.s2c_charlistcharacter_statstring, buf, off)
+	return off
+end
+
+-- dissect_s2c_charlist dissects a s2c CharList
+local function dissect_s2c_charlist(buf, tree, off)
+	tree:add_le(f.s2c_charlist_requestcount, buf(off, 2))
+	off = off + 2
+	tree:add_le(f.s2c_charlist_existcount, buf(off, 4))
+	off = off + 4
+	local save_CLReturned = buf(off, 2):le_uint()
+	tree:add_le(f.s2c_charlist_returnedcount, buf(off, 2))
+	off = off + 2
+	for i = 0, save_CLReturned - 1 do
+		local start = off
+		local sub = tree:add(buf(off, 0), "Characters[" .. i .. "]")
+		off = dissect_s2c_charlistcharacter(buf, sub, off)
+		sub:set_len(off - start)
+	end
+	return off
+end
+
+-- dissect_s2c_charupgrade dissects a s2c CharUpgrade
+local function dissect_s2c_charupgrade(buf, tree, off)
+	tree:add_le(f.s2c_charupgrade_result, buf(off, 4))
+	off = off + 4
+	return off
+end
+
+-- dissect_s2c_charlist2character dissects a s2c CharList2Character
+local function dissect_s2c_charlist2character(buf, tree, off)
+	tree:add_le(f.s2c_charlist2character_expirationdate, buf(off, 4))
+	off = off + 4
+	off = add_stringz(tree, f.s2c_charlist2character_name, buf, off)
+	off = add_stringz(tree, f.s2c_charlist2character_statstring, buf, off)
+	return off
+end
+
+-- dissect_s2c_charlist2 dissects a s2c CharList2
+local function dissect_s2c_charlist2(buf, tree, off)
+	tree:add_le(f.s2c_charlist2_requestcount, buf(off, 2))
+	off = off + 2
+	tree:add_le(f.s2c_charlist2_existcount, buf(off, 4))
+	off = off + 4
+	local save_CL2Returned = buf(off, 2):le_uint()
+	tree:add_le(f.s2c_charlist2_returnedcount, buf(off, 2))
+	off = off + 2
+	for i = 0, save_CL2Returned - 1 do
+		local start = off
+		local sub = tree:add(buf(off, 0), "Characters[" .. i .. "]")
+		off = dissect_s2c_charlist2character(buf, sub, off)
+		sub:set_len(off - start)
+	end
+	return off
+end
+
+local c2s_messages = {
+	[0x01] = dissect_c2s_startup,
+	[0x02] = dissect_c2s_charcreate,
+	[0x03] = dissect_c2s_creategame,
+	[0x04] = dissect_c2s_joingame,
+	[0x05] = dissect_c2s_gamelist,
+	[0x06] = dissect_c2s_gameinfo,
+	[0x07] = dissect_c2s_charlogon,
+	[0x0a] = dissect_c2s_chardelete,
+	[0x11] = dissect_c2s_requestladderdata,
+	[0x12] = dissect_c2s_motd,
+	[0x13] = dissect_c2s_cancelcreategame,
+	[0x16] = dissect_c2s_charrank,
+	[0x17] = dissect_c2s_charlist,
+	[0x18] = dissect_c2s_charupgrade,
+	[0x19] = dissect_c2s_charlist2,
+}
+
+local s2c_messages = {
+	[0x01] = dissect_s2c_startup,
+	[0x02] = dissect_s2c_charcreate,
+	[0x03] = dissect_s2c_creategame,
+	[0x04] = dissect_s2c_joingame,
+	[0x05] = dissect_s2c_gamelist,
+	[0x06] = dissect_s2c_gameinfo,
+	[0x07] = dissect_s2c_charlogon,
+	[0x0a] = dissect_s2c_chardelete,
+	[0x11] = dissect_s2c_requestladderdata,
+	[0x12] = dissect_s2c_motd,
+	[0x14] = dissect_s2c_createqueue,
+	[0x16] = dissect_s2c_charrank,
+	[0x17] = dissect_s2c_charlist,
+	[0x18] = dissect_s2c_charupgrade,
+	[0x19] = dissect_s2c_charlist2,
+}
+
+-- dissect_frame dissects the complete frame buf
+local function dissect_frame(buf, pinfo, tree, c2s)
+	local id = buf(2, 1):uint()
+	local dir, messages = "s2c", s2c_messages
+	if c2s then
+		dir, messages = "c2s", c2s_messages
+	end
+	local name = vs_mcp_messageid[id] or string.format("0x%02x", id)
+
+	local t = tree:add(mcp, buf(), "MCP " .. dir .. " " .. name)
+	t:add_le(f.length, buf(0, 2))
+	t:add(f.id, buf(2, 1))
+	if buf:len() == HEADER_LENGTH then
+		return name
+	end
+
+	local payload = buf(HEADER_LENGTH):tvb()
+	local handler = messages[id]
+	if not handler then
+		t:add(f.payload, payload())
+		return name
+	end
+	local ok, off = pcall(handler, payload, t, 0)
+	if not ok then
+		t:add_expert_info(PI_MALFORMED, PI_ERROR, "Malformed " .. name)
+	elseif off < payload:len() then
+		t:add(f.trailing, payload(off))
+	end
+	return name
+end
+
+function mcp.dissector(buf, pinfo, tree)
+	local c2s = pinfo.dst_port == mcp.prefs.port
+	local off = 0
+	local names = {}
+	local consumed = buf:len()
+
+	-- The client opens the connection with the protocol selector, which
+	-- precedes the MCP_STARTUP request.
+	if c2s and buf:len() >= 1 + HEADER_LENGTH and buf(0, 1):uint() == PROTOCOL_SELECTOR
+		and buf(3, 1):uint() == MCP_STARTUP then
+		tree:add(mcp, buf(0, 1)):add(f.selector, buf(0, 1))
+		off = 1
+	end
+
+	while off < buf:len() do
+		local rest = buf:len() - off
+		if rest < HEADER_LENGTH then
+			pinfo.desegment_offset = off
+			pinfo.desegment_len = DESEGMENT_ONE_MORE_SEGMENT
+			break
+		end
+		local n = buf(off, 2):le_uint()
+		if n < HEADER_LENGTH then
+			-- Not a MCP frame
+			consumed = off
+			break
+		end
+		if rest < n then
+			pinfo.desegment_offset = off
+			pinfo.desegment_len = n - rest
+			break
+		end
+		names[#names + 1] = dissect_frame(buf(off, n):tvb(), pinfo, tree, c2s)
+		off = off + n
+	end
+
+	if #names > 0 then
+		pinfo.cols.protocol = "MCP"
+		pinfo.cols.info = (c2s and "c2s " or "s2c ") .. table.concat(names, ", ")
+	end
+	return consumed
+end
+
+local port
+
+function mcp.prefs_changed()
+	local tcp = DissectorTable.get("tcp.port")
+	if port then
+		tcp:remove(port, mcp)
+	end
+	port = mcp.prefs.port
+	tcp:add(port, mcp)
+end
+
+mcp.prefs_changed()
@@ -4,25 +4,17 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/samlitowitz/bnet-encoding/pkg/encoding/bnet"
 	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
-	"github.com/samlitowitz/bnet-mcp/pkg/mcp/server"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/dissect"
 )
 
 func main() {
-	data := []byte{0x09, 0x00, 0x07, 0x01, 0x00, 0x00, 0x00}
+	data := []byte{0x07, 0x00, 0x07, 0x01, 0x00, 0x00, 0x00}
 
-	var header mcp.Header
-	err := bnet.Unmarshal(data[:3], &header)
+	frame, err := dissect.Dissect(mcp.ServerToClient, data)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	var charlogon server.CharLogon
-	err = bnet.Unmarshal(data[3:], &charlogon)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	fmt.Printf("%+v\n%+v\n", header, charlogon)
+	fmt.Print(frame)
 }
@@ -3,6 +3,10 @@ module github.com/samlitowitz/bnet-mcp
 go 1.12
 
 require (
-	github.com/samlitowitz/bnet-encoding v0.0.0-20190425132139-0d267bab7fd6 // indirect
+	github.com/samlitowitz/bnet-bncs v0.0.0-00010101000000-87fcb307f82a
+	github.com/samlitowitz/bnet-encoding v0.0.0-20190425132139-0d267bab7fd6
 	golang.org/x/tools v0.0.0-20190425222232-4eab536980eb // indirect
 )
+
+// Revision 87fcb307f82a2f1eb91123e0736ca88500bd0675 of Gopkg.lock
+replace github.com/samlitowitz/bnet-bncs => ./third_party/bnet-bncs
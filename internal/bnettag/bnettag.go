@@ -0,0 +1,27 @@
+// Package bnettag parses the struct tags read by the bnet package.
+package bnettag
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Parse returns the options of the bnet key in tag, mirroring the bnet
+// package's tag parsing. An option of the form key-value maps key to
+// value; any other option maps to the empty string.
+func Parse(tag reflect.StructTag) map[string]string {
+	out := make(map[string]string)
+	t := tag.Get("bnet")
+	if t == "-" || t == "" {
+		return out
+	}
+	for _, opt := range strings.Split(t, ",") {
+		keyVal := strings.Split(opt, "-")
+		if len(keyVal) == 1 {
+			out[keyVal[0]] = ""
+		} else {
+			out[keyVal[0]] = keyVal[1]
+		}
+	}
+	return out
+}
@@ -0,0 +1,25 @@
+package bnettag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		tag  reflect.StructTag
+		want map[string]string
+	}{
+		{``, map[string]string{}},
+		{`json:"name"`, map[string]string{}},
+		{`bnet:"-"`, map[string]string{}},
+		{`bnet:"bigendian"`, map[string]string{"bigendian": ""}},
+		{`bnet:"save-Count,size-uint8"`, map[string]string{"save": "Count", "size": "uint8"}},
+		{`json:"n" bnet:"len-Count"`, map[string]string{"len": "Count"}},
+	}
+	for _, tt := range tests {
+		if got := Parse(tt.tag); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.tag, got, tt.want)
+		}
+	}
+}
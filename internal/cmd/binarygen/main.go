@@ -0,0 +1,459 @@
+// Binarygen generates AppendBinary, MarshalBinary, UnmarshalBinary and
+// DecodeBinary methods for structs encoded with the bnet package. The
+// generated code produces the same bytes as bnet.Marshal and bnet.Unmarshal
+// without reflection.
+//
+// Usage:
+//
+//	binarygen -type=T1,T2 [-output=file] [dir]
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/types"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/samlitowitz/bnet-mcp/internal/bnettag"
+	"github.com/samlitowitz/bnet-mcp/internal/typecheck"
+)
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of type names; must be set")
+	output    = flag.String("output", "", "output file name; default srcdir/binary_generated.go")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("binarygen: ")
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	outputName := *output
+	if outputName == "" {
+		outputName = filepath.Join(dir, "binary_generated.go")
+	}
+
+	pkg, err := typecheck.Dir(dir, outputName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	g := &generator{pkg: pkg, imports: map[string]string{}}
+	for _, name := range strings.Split(*typeNames, ",") {
+		if err := g.generate(name); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	src, err := format.Source(g.file(strings.Join(os.Args[1:], " ")))
+	if err != nil {
+		log.Fatalf("formatting output: %s", err)
+	}
+	if err := ioutil.WriteFile(outputName, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type generator struct {
+	pkg     *types.Package
+	imports map[string]string // path to name
+	buf     bytes.Buffer
+	tmp     int
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) file(args string) []byte {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by \"binarygen %s\"; DO NOT EDIT.\n\n", args)
+	fmt.Fprintf(&out, "package %s\n\n", g.pkg.Name())
+
+	g.imports["github.com/samlitowitz/bnet-encoding/pkg/encoding/bnet"] = "bnet"
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	out.WriteString("import (\n")
+	for _, std := range []bool{true, false} {
+		for _, path := range paths {
+			if isStd(path) == std {
+				fmt.Fprintf(&out, "\t%q\n", path)
+			}
+		}
+		if std {
+			out.WriteString("\n")
+		}
+	}
+	out.WriteString(")\n")
+	out.Write(g.buf.Bytes())
+	return out.Bytes()
+}
+
+// isStd reports whether path belongs to the standard library.
+func isStd(path string) bool {
+	return !strings.Contains(strings.SplitN(path, "/", 2)[0], ".")
+}
+
+// qualifier names types of other packages by package name and records
+// the import.
+func (g *generator) qualifier(p *types.Package) string {
+	if p == g.pkg {
+		return ""
+	}
+	g.imports[p.Path()] = p.Name()
+	return p.Name()
+}
+
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, g.qualifier)
+}
+
+func (g *generator) generate(name string) error {
+	obj := g.pkg.Scope().Lookup(name)
+	if obj == nil {
+		return fmt.Errorf("type %s not found", name)
+	}
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return fmt.Errorf("type %s is not a struct", name)
+	}
+
+	fields, err := parseFields(name, st)
+	if err != nil {
+		return err
+	}
+
+	g.printf("\n// AppendBinary appends the wire encoding of x to b.\n")
+	g.printf("func (x %s) AppendBinary(b []byte) ([]byte, error) {\n", name)
+	if hasNested(fields) {
+		g.printf("var err error\n")
+	}
+	for _, f := range fields {
+		if err := g.encode(name, f, "x."+f.name, f.typ, f.tags); err != nil {
+			return err
+		}
+	}
+	g.printf("return b, nil\n}\n")
+
+	g.printf("\n// MarshalBinary returns the wire encoding of x.\n")
+	g.printf("func (x %s) MarshalBinary() ([]byte, error) {\n", name)
+	g.printf("return x.AppendBinary(nil)\n}\n")
+
+	g.printf("\n// UnmarshalBinary decodes the wire encoding in data into x.\n")
+	g.printf("func (x *%s) UnmarshalBinary(data []byte) error {\n", name)
+	if hasMethod(obj.Type(), "UnmarshalBNet") {
+		// Hand written decoders take precedence over the generated one.
+		g.printf("return x.UnmarshalBNet(data)\n}\n")
+		return nil
+	}
+	g.printf("_, err := x.decodeBinary(data, 0)\nreturn err\n}\n")
+
+	g.printf("\n// DecodeBinary decodes x from the start of data and returns the number\n// of bytes consumed.\n")
+	g.printf("func (x *%s) DecodeBinary(data []byte) (int, error) {\n", name)
+	g.printf("return x.decodeBinary(data, 0)\n}\n")
+
+	g.printf("\n// decodeBinary decodes x from data starting at off and returns the\n// offset following x.\n")
+	g.printf("func (x *%s) decodeBinary(data []byte, off int) (int, error) {\n", name)
+	if hasNested(fields) {
+		g.printf("var err error\n")
+	}
+	used := map[string]bool{}
+	for _, f := range fields {
+		if l, ok := f.tags["len"]; ok {
+			used[l] = true
+		}
+	}
+	saved := map[string]bool{}
+	for _, f := range fields {
+		if err := g.decode(name, f, "x."+f.name, f.typ, f.tags, saved); err != nil {
+			return err
+		}
+		if s, ok := f.tags["save"]; ok && used[s] {
+			g.printf("save%s := int(x.%s)\n", s, f.name)
+			saved[s] = true
+		}
+	}
+	g.printf("return off, nil\n}\n")
+	return nil
+}
+
+type field struct {
+	name string
+	typ  types.Type
+	tags map[string]string
+}
+
+func parseFields(structName string, st *types.Struct) ([]field, error) {
+	var fields []field
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		if !v.Exported() {
+			continue
+		}
+		if v.Anonymous() {
+			return nil, fmt.Errorf("%s.%s: embedded fields are not supported", structName, v.Name())
+		}
+		fields = append(fields, field{
+			name: v.Name(),
+			typ:  v.Type(),
+			tags: bnettag.Parse(reflect.StructTag(st.Tag(i))),
+		})
+	}
+	return fields, nil
+}
+
+func hasNested(fields []field) bool {
+	for _, f := range fields {
+		if containsStruct(f.typ) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsStruct(t types.Type) bool {
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		return true
+	case *types.Array:
+		return containsStruct(u.Elem())
+	case *types.Slice:
+		return containsStruct(u.Elem())
+	}
+	return false
+}
+
+// isByte reports whether t is an array or slice of unnamed uint8.
+func isByte(t types.Type) bool {
+	var elem types.Type
+	switch u := t.(type) {
+	case *types.Array:
+		elem = u.Elem()
+	case *types.Slice:
+		elem = u.Elem()
+	default:
+		return false
+	}
+	b, ok := elem.(*types.Basic)
+	return ok && b.Kind() == types.Uint8
+}
+
+func hasMethod(t types.Type, name string) bool {
+	ms := types.NewMethodSet(types.NewPointer(t))
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Obj().Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) index() string {
+	return g.tmpName("i")
+}
+
+func (g *generator) tmpName(prefix string) string {
+	g.tmp++
+	return fmt.Sprintf("%s%d", prefix, g.tmp)
+}
+
+// convert returns v converted to typ unless v is already of that type.
+func convert(typ, v, vtyp string) string {
+	if typ == vtyp {
+		return v
+	}
+	return typ + "(" + v + ")"
+}
+
+func (g *generator) encode(structName string, f field, expr string, t types.Type, tags map[string]string) error {
+	_, bigendian := tags["bigendian"]
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch u.Kind() {
+		case types.Uint8:
+			g.printf("b = append(b, byte(%s))\n", expr)
+		case types.Uint16:
+			g.printf("b = append(b, %s)\n", byteList(expr, 2, bigendian))
+		case types.Uint32:
+			g.printf("b = append(b, %s)\n", byteList(expr, 4, bigendian))
+		case types.Uint64:
+			g.printf("b = append(b, %s)\n", byteList(expr, 8, bigendian))
+		case types.Bool:
+			n, err := boolSize(structName, f, tags)
+			if err != nil {
+				return err
+			}
+			g.printf("if %s {\nb = append(b, 0x01%s)\n} else {\nb = append(b, 0x00%s)\n}\n", expr, strings.Repeat(", 0x00", n-1), strings.Repeat(", 0x00", n-1))
+		case types.String:
+			g.printf("b = append(b, %s...)\nb = append(b, 0x00)\n", expr)
+		default:
+			return fmt.Errorf("%s.%s: unsupported type %s", structName, f.name, t)
+		}
+	case *types.Array, *types.Slice:
+		if isByte(u) {
+			if _, ok := u.(*types.Array); ok {
+				expr += "[:]"
+			}
+			g.printf("b = append(b, %s...)\n", expr)
+			return nil
+		}
+		var elem types.Type
+		if a, ok := u.(*types.Array); ok {
+			elem = a.Elem()
+		} else {
+			elem = u.(*types.Slice).Elem()
+			if b, ok := elem.Underlying().(*types.Basic); ok && b.Kind() == types.String {
+				return fmt.Errorf("%s.%s: string slices are not supported", structName, f.name)
+			}
+		}
+		i := g.index()
+		g.printf("for %s := range %s {\n", i, expr)
+		if err := g.encode(structName, f, expr+"["+i+"]", elem, tags); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case *types.Struct:
+		g.printf("if b, err = %s.AppendBinary(b); err != nil {\nreturn nil, err\n}\n", expr)
+	default:
+		return fmt.Errorf("%s.%s: unsupported type %s", structName, f.name, t)
+	}
+	return nil
+}
+
+func (g *generator) decode(structName string, f field, expr string, t types.Type, tags map[string]string, saved map[string]bool) error {
+	_, bigendian := tags["bigendian"]
+	order := "binary.LittleEndian"
+	if bigendian {
+		order = "binary.BigEndian"
+	}
+	typ := g.typeString(t)
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch u.Kind() {
+		case types.Uint8:
+			g.checkLen(structName, f, "1")
+			g.printf("%s = %s\noff++\n", expr, convert(typ, "data[off]", "uint8"))
+		case types.Uint16, types.Uint32, types.Uint64:
+			bits := map[types.BasicKind]int{types.Uint16: 16, types.Uint32: 32, types.Uint64: 64}[u.Kind()]
+			g.imports["encoding/binary"] = "binary"
+			g.checkLen(structName, f, fmt.Sprint(bits/8))
+			v := fmt.Sprintf("%s.Uint%d(data[off:])", order, bits)
+			g.printf("%s = %s\noff += %d\n", expr, convert(typ, v, fmt.Sprintf("uint%d", bits)), bits/8)
+		case types.Bool:
+			n, err := boolSize(structName, f, tags)
+			if err != nil {
+				return err
+			}
+			g.checkLen(structName, f, fmt.Sprint(n))
+			v := "data[off]"
+			if n == 4 {
+				g.imports["encoding/binary"] = "binary"
+				v = order + ".Uint32(data[off:])"
+			}
+			g.printf("switch %s {\ncase 0:\n%s = false\ncase 1:\n%s = true\ndefault:\n", v, expr, expr)
+			g.printf("return off, &bnet.InvalidValueError{Struct: %q, Field: %q, Value: data[off : off+%d]}\n}\noff += %d\n", structName, f.name, n, n)
+		case types.String:
+			g.imports["bytes"] = "bytes"
+			n := g.tmpName("n")
+			g.printf("%s := bytes.IndexByte(data[off:], 0x00)\n", n)
+			g.printf("if %s < 0 {\n", n)
+			g.printf("return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: %q, Field: %q}\n}\n", structName, f.name)
+			g.printf("%s = %s(data[off : off+%s])\noff += %s + 1\n", expr, typ, n, n)
+		default:
+			return fmt.Errorf("%s.%s: unsupported type %s", structName, f.name, t)
+		}
+	case *types.Array:
+		if isByte(u) {
+			g.checkLen(structName, f, fmt.Sprint(u.Len()))
+			g.printf("copy(%s[:], data[off:])\noff += %d\n", expr, u.Len())
+			return nil
+		}
+		i := g.index()
+		g.printf("for %s := range %s {\n", i, expr)
+		if err := g.decode(structName, f, expr+"["+i+"]", u.Elem(), tags, saved); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case *types.Slice:
+		name, ok := tags["len"]
+		if !ok {
+			return fmt.Errorf("%s.%s: slices require a len tag", structName, f.name)
+		}
+		if !saved[name] {
+			return fmt.Errorf("%s.%s: len-%s refers to an unsaved value", structName, f.name, name)
+		}
+		// Every element occupies at least one byte so a count exceeding
+		// the remaining data cannot be satisfied.
+		g.printf("if save%s > len(data)-off {\n", name)
+		g.printf("return off, &bnet.IndexOutOfRangeError{N: int64(save%s), Offset: int64(off), Struct: %q, Field: %q}\n}\n", name, structName, f.name)
+		// Like bnet.Unmarshal, an empty slice decodes as nil.
+		g.printf("%s = nil\nif save%s > 0 {\n", expr, name)
+		g.printf("%s = make(%s, save%s)\n", expr, typ, name)
+		if isByte(u) {
+			g.printf("off += copy(%s, data[off:])\n}\n", expr)
+			return nil
+		}
+		i := g.index()
+		g.printf("for %s := range %s {\n", i, expr)
+		if err := g.decode(structName, f, expr+"["+i+"]", u.Elem(), tags, saved); err != nil {
+			return err
+		}
+		g.printf("}\n}\n")
+	case *types.Struct:
+		g.printf("if off, err = %s.decodeBinary(data, off); err != nil {\nreturn off, err\n}\n", expr)
+	default:
+		return fmt.Errorf("%s.%s: unsupported type %s", structName, f.name, t)
+	}
+	return nil
+}
+
+func (g *generator) checkLen(structName string, f field, n string) {
+	g.printf("if len(data)-off < %s {\n", n)
+	g.printf("return off, &bnet.IndexOutOfRangeError{N: %s, Offset: int64(off), Struct: %q, Field: %q}\n}\n", n, structName, f.name)
+}
+
+func boolSize(structName string, f field, tags map[string]string) (int, error) {
+	switch tags["size"] {
+	case "uint8":
+		return 1, nil
+	case "uint32":
+		return 4, nil
+	}
+	return 0, fmt.Errorf("%s.%s: bool fields require a size-uint8 or size-uint32 tag", structName, f.name)
+}
+
+// byteList returns the comma separated bytes of the n byte integer expr.
+func byteList(expr string, n int, bigendian bool) string {
+	parts := make([]string, n)
+	for i := 0; i < n; i++ {
+		shift := i * 8
+		if bigendian {
+			shift = (n - 1 - i) * 8
+		}
+		if shift == 0 {
+			parts[i] = fmt.Sprintf("byte(%s)", expr)
+		} else {
+			parts[i] = fmt.Sprintf("byte(%s>>%d)", expr, shift)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
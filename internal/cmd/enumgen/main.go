@@ -0,0 +1,129 @@
+// Enumgen generates the init function registering the named values of
+// enumerated types with mcp.RegisterEnum, so the JSON form of messages
+// refers to every constant of the types by name.
+//
+// Usage:
+//
+//	enumgen -type=T1,T2 [-output=file] [dir]
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/types"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/samlitowitz/bnet-mcp/internal/typecheck"
+)
+
+const mcpPath = "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of type names; must be set")
+	output    = flag.String("output", "", "output file name; default srcdir/enum_generated.go")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("enumgen: ")
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	outputName := *output
+	if outputName == "" {
+		outputName = filepath.Join(dir, "enum_generated.go")
+	}
+
+	src, err := generate(dir, outputName, strings.Split(*typeNames, ","), strings.Join(os.Args[1:], " "))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(outputName, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the source registering the constants of the named
+// types declared in the package in dir. args are recorded in the header.
+func generate(dir, outputName string, names []string, args string) ([]byte, error) {
+	pkg, err := typecheck.Dir(dir, outputName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The mcp package registers its own types without importing itself.
+	register := "mcp.RegisterEnum"
+	if pkg.Scope().Lookup("RegisterEnum") != nil {
+		register = "RegisterEnum"
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by \"enumgen %s\"; DO NOT EDIT.\n\n", args)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg.Name())
+	if register != "RegisterEnum" {
+		fmt.Fprintf(&buf, "import %q\n\n", mcpPath)
+	}
+	buf.WriteString("func init() {\n")
+	for _, name := range names {
+		values, err := constants(pkg, name)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "%s(\n", register)
+		for _, v := range values {
+			fmt.Fprintf(&buf, "%s,\n", v.Name())
+		}
+		buf.WriteString(")\n")
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting output: %s", err)
+	}
+	return src, nil
+}
+
+// constants returns the exported constants of the named type in
+// declaration order.
+func constants(pkg *types.Package, name string) ([]*types.Const, error) {
+	obj := pkg.Scope().Lookup(name)
+	if obj == nil {
+		return nil, fmt.Errorf("type %s not found", name)
+	}
+	if _, ok := obj.(*types.TypeName); !ok {
+		return nil, fmt.Errorf("%s is not a type", name)
+	}
+	if b, ok := obj.Type().Underlying().(*types.Basic); !ok || b.Info()&types.IsUnsigned == 0 {
+		return nil, fmt.Errorf("type %s is not an unsigned integer", name)
+	}
+
+	var values []*types.Const
+	for _, n := range pkg.Scope().Names() {
+		c, ok := pkg.Scope().Lookup(n).(*types.Const)
+		if ok && c.Exported() && types.Identical(c.Type(), obj.Type()) {
+			values = append(values, c)
+		}
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("type %s has no constants", name)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Pos() < values[j].Pos()
+	})
+	return values, nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedUpToDate fails when a constant is added to an enumerated
+// type without its registration being regenerated.
+func TestGeneratedUpToDate(t *testing.T) {
+	for _, tc := range []struct {
+		dir   string
+		types string
+	}{
+		{"../../../pkg/mcp", "MessageID,CharacterClass,Difficulty"},
+		{"../../../pkg/mcp/server", "StartupResult,CharCreateResult,CreateGameResult,JoinGameResult,CharLogonResult,CharDeleteResult,CharRankResult,CharUpgradeResult"},
+	} {
+		file := filepath.Join(tc.dir, "enum_generated.go")
+		want, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := generate(tc.dir, file, strings.Split(tc.types, ","), "-type="+tc.types)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date; run go generate in %s", file, tc.dir)
+		}
+	}
+}
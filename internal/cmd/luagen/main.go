@@ -0,0 +1,512 @@
+//go:generate go run . -output ../../../contrib/wireshark/mcp.lua
+
+// Luagen generates a Wireshark Lua dissector for MCP from the message
+// types registered by the client and server packages. Field layouts,
+// including bnet save and len tags, bool sizes, byte order and fixed
+// arrays, are read from the struct definitions so the dissector decodes
+// exactly what the Go code encodes.
+//
+// Usage:
+//
+//	luagen [-output=file] [-port=n]
+//
+// Install the output in the Wireshark plugins directory, or load it with
+// "wireshark -X lua_script:mcp.lua".
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/samlitowitz/bnet-mcp/internal/bnettag"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+	_ "github.com/samlitowitz/bnet-mcp/pkg/mcp/client"
+	_ "github.com/samlitowitz/bnet-mcp/pkg/mcp/server"
+)
+
+var (
+	output = flag.String("output", "", "output file name; default standard output")
+	port   = flag.Int("port", 6112, "default TCP port of the dissector")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("luagen: ")
+	flag.Parse()
+
+	src, err := generate(*port)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *output == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// stringLists maps the null separated string fields read by hand written
+// decoders to the fields counting their strings, since their layout cannot
+// be read from the struct.
+var stringLists = map[string]string{
+	"GameInfo.CharacterNames": "CharacterCount", // server.GameInfo.DecodeBinary
+}
+
+// directions are the message directions in output order
+var directions = []mcp.Direction{mcp.ClientToServer, mcp.ServerToClient}
+
+// generate returns the dissector for the registered messages, registered
+// on port.
+func generate(port int) ([]byte, error) {
+	g := &generator{
+		enums:    map[reflect.Type]string{},
+		funcs:    map[string]bool{},
+		declared: map[string]bool{},
+	}
+	idType := reflect.TypeOf(mcp.MessageID(0))
+	g.enum(idType)
+
+	messages := map[mcp.Direction][]string{}
+	for _, dir := range directions {
+		for id := 0; id <= 0xff; id++ {
+			msg, err := mcp.New(dir, mcp.MessageID(id))
+			if err != nil {
+				continue
+			}
+			name, err := g.structFunc(dir, reflect.TypeOf(msg).Elem())
+			if err != nil {
+				return nil, err
+			}
+			messages[dir] = append(messages[dir], fmt.Sprintf("\t[0x%02x] = %s,\n", id, name))
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "-- Code generated by \"luagen %s\"; DO NOT EDIT.\n", strings.Join(os.Args[1:], " "))
+	fmt.Fprintf(&out, "--\n-- Wireshark dissector for the Diablo II realm server protocol (MCP).\n")
+	fmt.Fprintf(&out, "-- Load with \"wireshark -X lua_script:mcp.lua\" or copy into the plugins\n-- directory.\n\n")
+	fmt.Fprintf(&out, "local mcp = Proto(\"mcp\", \"Diablo II Realm Server Protocol (MCP)\")\n")
+	fmt.Fprintf(&out, "mcp.prefs.port = Pref.uint(\"TCP port\", %d, \"TCP port of the realm server\")\n\n", port)
+	fmt.Fprintf(&out, "local HEADER_LENGTH = %d\n", mcp.HeaderLength)
+	fmt.Fprintf(&out, "local PROTOCOL_SELECTOR = 0x%02x\n", mcp.ProtocolSelector)
+	fmt.Fprintf(&out, "local MCP_STARTUP = 0x%02x\n", uint8(mcp.McpStartup))
+
+	out.Write(g.tables.Bytes())
+
+	idTable := g.enums[idType]
+	out.WriteString("\nlocal f = mcp.fields\n")
+	out.WriteString("f.selector = ProtoField.uint8(\"mcp.selector\", \"Protocol selector\", base.HEX)\n")
+	out.WriteString("f.length = ProtoField.uint16(\"mcp.length\", \"Length\", base.DEC)\n")
+	fmt.Fprintf(&out, "f.id = ProtoField.uint8(\"mcp.id\", \"Message ID\", base.HEX, %s)\n", idTable)
+	out.WriteString("f.payload = ProtoField.bytes(\"mcp.payload\", \"Payload\")\n")
+	out.WriteString("f.trailing = ProtoField.bytes(\"mcp.trailing\", \"Trailing data\")\n")
+	out.Write(g.fields.Bytes())
+
+	out.WriteString(luaHelpers)
+	out.Write(g.body.Bytes())
+
+	for _, dir := range directions {
+		fmt.Fprintf(&out, "\nlocal %s_messages = {\n", dir)
+		for _, m := range messages[dir] {
+			out.WriteString(m)
+		}
+		out.WriteString("}\n")
+	}
+
+	fmt.Fprintf(&out, luaDissector, idTable)
+	return out.Bytes(), nil
+}
+
+type generator struct {
+	tables bytes.Buffer // value string tables
+	fields bytes.Buffer // ProtoField declarations
+	body   bytes.Buffer // struct dissection functions
+
+	enums    map[reflect.Type]string // value string table names
+	funcs    map[string]bool         // generated functions
+	declared map[string]bool         // declared fields
+}
+
+// enum returns the name of the value string table of t, or "nil" if t has
+// no registered values.
+func (g *generator) enum(t reflect.Type) string {
+	if name, ok := g.enums[t]; ok {
+		return name
+	}
+	values := mcp.EnumValues(t)
+	if values == nil {
+		return "nil"
+	}
+
+	name := "vs_" + strings.ToLower(path.Base(t.PkgPath())+"_"+t.Name())
+	g.enums[t] = name
+
+	keys := make([]uint64, 0, len(values))
+	for v := range values {
+		keys = append(keys, v)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	fmt.Fprintf(&g.tables, "\nlocal %s = {\n", name)
+	for _, v := range keys {
+		fmt.Fprintf(&g.tables, "\t[0x%02x] = %q,\n", v, values[v])
+	}
+	g.tables.WriteString("}\n")
+	return name
+}
+
+// structFunc generates the function dissecting the struct t sent in dir,
+// and those of the structs it contains, returning its name.
+func (g *generator) structFunc(dir mcp.Direction, t reflect.Type) (string, error) {
+	lower := strings.ToLower(t.Name())
+	name := fmt.Sprintf("dissect_%s_%s", dir, lower)
+	if g.funcs[name] {
+		return name, nil
+	}
+	g.funcs[name] = true
+
+	fg := &funcGen{
+		g:      g,
+		dir:    dir,
+		st:     t,
+		abbrev: fmt.Sprintf("mcp.%s.%s", dir, lower),
+		ident:  fmt.Sprintf("f.%s_%s", dir, lower),
+		used:   map[string]bool{},
+		saved:  map[string]bool{},
+	}
+	var fields []reflect.StructField
+	var tags []map[string]string
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		if sf.Anonymous {
+			return "", fmt.Errorf("%s.%s: embedded fields are not supported", t.Name(), sf.Name)
+		}
+		tag := bnettag.Parse(sf.Tag)
+		if l, ok := tag["len"]; ok {
+			fg.used[l] = true
+		}
+		if c, ok := stringLists[t.Name()+"."+sf.Name]; ok {
+			fg.used[c] = true
+		}
+		fields = append(fields, sf)
+		tags = append(tags, tag)
+	}
+	// Counts of string lists are saved like those of len tags.
+	for i, sf := range fields {
+		if fg.used[sf.Name] {
+			tags[i]["save"] = sf.Name
+		}
+	}
+
+	fmt.Fprintf(&fg.buf, "\n-- %s dissects a %s %s\n", name, dir, t.Name())
+	fmt.Fprintf(&fg.buf, "local function %s(buf, tree, off)\n", name)
+	for i, sf := range fields {
+		if err := fg.field(1, sf.Name, sf.Type, tags[i]); err != nil {
+			return "", err
+		}
+	}
+	fg.buf.WriteString("\treturn off\nend\n")
+
+	// Nested structs were generated while walking the fields, so their
+	// functions precede this one.
+	g.body.Write(fg.buf.Bytes())
+	return name, nil
+}
+
+// funcGen generates the dissection function of one struct
+type funcGen struct {
+	g      *generator
+	dir    mcp.Direction
+	st     reflect.Type
+	abbrev string
+	ident  string
+	used   map[string]bool // saved values referred to by len tags
+	saved  map[string]bool
+	buf    bytes.Buffer
+}
+
+func (fg *funcGen) printf(depth int, format string, args ...interface{}) {
+	fg.buf.WriteString(strings.Repeat("\t", depth))
+	fmt.Fprintf(&fg.buf, format, args...)
+}
+
+// protoField declares the field name of the struct with the ProtoField
+// constructor and arguments, returning its identifier.
+func (fg *funcGen) protoField(name, ctor string, args ...string) string {
+	lower := strings.ToLower(name)
+	ident := fg.ident + "_" + lower
+	if fg.g.declared[ident] {
+		return ident
+	}
+	fg.g.declared[ident] = true
+
+	all := append([]string{fmt.Sprintf("%q", fg.abbrev+"."+lower), fmt.Sprintf("%q", name)}, args...)
+	for len(all) > 2 && all[len(all)-1] == "nil" {
+		all = all[:len(all)-1]
+	}
+	fmt.Fprintf(&fg.g.fields, "%s = ProtoField.%s(%s)\n", ident, ctor, strings.Join(all, ", "))
+	return ident
+}
+
+// field generates the dissection of the field name of type t.
+func (fg *funcGen) field(depth int, name string, t reflect.Type, tags map[string]string) error {
+	_, bigendian := tags["bigendian"]
+	add := "add_le"
+	if bigendian {
+		add = "add"
+	}
+
+	switch t.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := int(t.Size())
+		vs := fg.g.enum(t)
+		display := "base.DEC"
+		if vs != "nil" {
+			display = "base.HEX"
+		}
+		f := fg.protoField(name, fmt.Sprintf("uint%d", n*8), display, vs)
+		if s, ok := tags["save"]; ok && fg.used[s] {
+			if n > 4 {
+				return fg.errorf(name, "save tags on %d byte integers are not supported", n)
+			}
+			get := "le_uint"
+			if bigendian {
+				get = "uint"
+			}
+			fg.printf(depth, "local save_%s = buf(off, %d):%s()\n", s, n, get)
+			fg.saved[s] = true
+		}
+		fg.printf(depth, "tree:%s(%s, buf(off, %d))\n", add, f, n)
+		fg.printf(depth, "off = off + %d\n", n)
+	case reflect.Bool:
+		var n int
+		switch tags["size"] {
+		case "uint8":
+			n = 1
+		case "uint32":
+			n = 4
+		default:
+			return fg.errorf(name, "bool fields require a size-uint8 or size-uint32 tag")
+		}
+		f := fg.protoField(name, "bool", "base.NONE")
+		fg.printf(depth, "tree:%s(%s, buf(off, %d))\n", add, f, n)
+		fg.printf(depth, "off = off + %d\n", n)
+	case reflect.String:
+		f := fg.protoField(name, "stringz")
+		if c, ok := stringLists[fg.st.Name()+"."+name]; ok {
+			if !fg.saved[c] {
+				return fg.errorf(name, "the count %s must precede the strings", c)
+			}
+			fg.printf(depth, "if save_%s == 0 then\n", c)
+			fg.printf(depth+1, "-- An empty list is sent as a single empty string\n")
+			fg.printf(depth+1, "if off < buf:len() and buf(off, 1):uint() == 0 then\n")
+			fg.printf(depth+2, "tree:add(%s, buf(off, 1))\n", f)
+			fg.printf(depth+2, "off = off + 1\n")
+			fg.printf(depth+1, "end\n")
+			fg.printf(depth, "else\n")
+			fg.printf(depth+1, "for i = 1, save_%s do\n", c)
+			fg.printf(depth+2, "off = add_stringz(tree, %s, buf, off)\n", f)
+			fg.printf(depth+1, "end\n")
+			fg.printf(depth, "end\n")
+			return nil
+		}
+		fg.printf(depth, "off = add_stringz(tree, %s, buf, off)\n", f)
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Elem().Name() == "uint8" {
+			n := t.Len()
+			f := ""
+			if strings.HasSuffix(name, "IP") && n == 4 {
+				f = fg.protoField(name, "ipv4")
+			} else {
+				f = fg.protoField(name, "bytes")
+			}
+			fg.printf(depth, "tree:add(%s, buf(off, %d))\n", f, n)
+			fg.printf(depth, "off = off + %d\n", n)
+			return nil
+		}
+		return fg.elements(depth, name, fmt.Sprint(t.Len()-1), t.Elem(), tags)
+	case reflect.Slice:
+		s, ok := tags["len"]
+		if !ok {
+			return fg.errorf(name, "slices require a len tag")
+		}
+		if !fg.saved[s] {
+			return fg.errorf(name, "len-%s refers to an unsaved value", s)
+		}
+		if t.Elem().Kind() == reflect.Uint8 && t.Elem().Name() == "uint8" {
+			f := fg.protoField(name, "bytes")
+			fg.printf(depth, "if save_%s > 0 then\n", s)
+			fg.printf(depth+1, "tree:add(%s, buf(off, save_%s))\n", f, s)
+			fg.printf(depth+1, "off = off + save_%s\n", s)
+			fg.printf(depth, "end\n")
+			return nil
+		}
+		return fg.elements(depth, name, "save_"+s+" - 1", t.Elem(), tags)
+	case reflect.Struct:
+		fn, err := fg.g.structFunc(fg.dir, t)
+		if err != nil {
+			return err
+		}
+		fg.printf(depth, "do\n")
+		fg.nested(depth+1, fmt.Sprintf("%q", name), fn)
+		fg.printf(depth, "end\n")
+	default:
+		return fg.errorf(name, "unsupported type %s", t)
+	}
+	return nil
+}
+
+// elements generates the dissection of consecutive elements of type elem
+// indexed from 0 to last.
+func (fg *funcGen) elements(depth int, name, last string, elem reflect.Type, tags map[string]string) error {
+	fg.printf(depth, "for i = 0, %s do\n", last)
+	if elem.Kind() == reflect.Struct {
+		fn, err := fg.g.structFunc(fg.dir, elem)
+		if err != nil {
+			return err
+		}
+		fg.nested(depth+1, fmt.Sprintf("\"%s[\" .. i .. \"]\"", name), fn)
+	} else if err := fg.field(depth+1, name, elem, tags); err != nil {
+		return err
+	}
+	fg.printf(depth, "end\n")
+	return nil
+}
+
+// nested generates a subtree labelled label holding the struct dissected
+// by fn.
+func (fg *funcGen) nested(depth int, label, fn string) {
+	fg.printf(depth, "local start = off\n")
+	fg.printf(depth, "local sub = tree:add(buf(off, 0), %s)\n", label)
+	fg.printf(depth, "off = %s(buf, sub, off)\n", fn)
+	fg.printf(depth, "sub:set_len(off - start)\n")
+}
+
+func (fg *funcGen) errorf(name, format string, args ...interface{}) error {
+	return fmt.Errorf("%s.%s: %s", fg.st.Name(), name, fmt.Sprintf(format, args...))
+}
+
+const luaHelpers = `
+-- stringz_length returns the length of the NUL terminated string at off,
+-- including the terminator. Unterminated strings are an error.
+local function stringz_length(buf, off)
+	for i = off, buf:len() - 1 do
+		if buf(i, 1):uint() == 0 then
+			return i - off + 1
+		end
+	end
+	error("unterminated string at offset " .. off)
+end
+
+-- add_stringz adds the NUL terminated string at off to tree as field and
+-- returns the offset following it.
+local function add_stringz(tree, field, buf, off)
+	local n = stringz_length(buf, off)
+	tree:add(field, buf(off, n))
+	return off + n
+end
+`
+
+// luaDissector is the frame splitting and dispatch code. The verb is the
+// name of the message ID value string table.
+const luaDissector = `
+-- dissect_frame dissects the complete frame buf
+local function dissect_frame(buf, pinfo, tree, c2s)
+	local id = buf(2, 1):uint()
+	local dir, messages = "s2c", s2c_messages
+	if c2s then
+		dir, messages = "c2s", c2s_messages
+	end
+	local name = %[1]s[id] or string.format("0x%%02x", id)
+
+	local t = tree:add(mcp, buf(), "MCP " .. dir .. " " .. name)
+	t:add_le(f.length, buf(0, 2))
+	t:add(f.id, buf(2, 1))
+	if buf:len() == HEADER_LENGTH then
+		return name
+	end
+
+	local payload = buf(HEADER_LENGTH):tvb()
+	local handler = messages[id]
+	if not handler then
+		t:add(f.payload, payload())
+		return name
+	end
+	local ok, off = pcall(handler, payload, t, 0)
+	if not ok then
+		t:add_expert_info(PI_MALFORMED, PI_ERROR, "Malformed " .. name)
+	elseif off < payload:len() then
+		t:add(f.trailing, payload(off))
+	end
+	return name
+end
+
+function mcp.dissector(buf, pinfo, tree)
+	local c2s = pinfo.dst_port == mcp.prefs.port
+	local off = 0
+	local names = {}
+	local consumed = buf:len()
+
+	-- The client opens the connection with the protocol selector, which
+	-- precedes the MCP_STARTUP request.
+	if c2s and buf:len() >= 1 + HEADER_LENGTH and buf(0, 1):uint() == PROTOCOL_SELECTOR
+		and buf(3, 1):uint() == MCP_STARTUP then
+		tree:add(mcp, buf(0, 1)):add(f.selector, buf(0, 1))
+		off = 1
+	end
+
+	while off < buf:len() do
+		local rest = buf:len() - off
+		if rest < HEADER_LENGTH then
+			pinfo.desegment_offset = off
+			pinfo.desegment_len = DESEGMENT_ONE_MORE_SEGMENT
+			break
+		end
+		local n = buf(off, 2):le_uint()
+		if n < HEADER_LENGTH then
+			-- Not a MCP frame
+			consumed = off
+			break
+		end
+		if rest < n then
+			pinfo.desegment_offset = off
+			pinfo.desegment_len = n - rest
+			break
+		end
+		names[#names + 1] = dissect_frame(buf(off, n):tvb(), pinfo, tree, c2s)
+		off = off + n
+	end
+
+	if #names > 0 then
+		pinfo.cols.protocol = "MCP"
+		pinfo.cols.info = (c2s and "c2s " or "s2c ") .. table.concat(names, ", ")
+	end
+	return consumed
+end
+
+local port
+
+function mcp.prefs_changed()
+	local tcp = DissectorTable.get("tcp.port")
+	if port then
+		tcp:remove(port, mcp)
+	end
+	port = mcp.prefs.port
+	tcp:add(port, mcp)
+end
+
+mcp.prefs_changed()
+`
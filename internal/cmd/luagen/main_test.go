@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+const generatedFile = "../../../contrib/wireshark/mcp.lua"
+
+// TestGeneratedUpToDate fails when the message structs change without the
+// dissector being regenerated.
+func TestGeneratedUpToDate(t *testing.T) {
+	want, err := ioutil.ReadFile(generatedFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := generate(6112)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first line records the generator's arguments.
+	if !bytes.Equal(skipLine(got), skipLine(want)) {
+		t.Errorf("%s is out of date; run go generate in internal/cmd/luagen", generatedFile)
+	}
+}
+
+func skipLine(b []byte) []byte {
+	if i := bytes.IndexByte(b, '\n'); i >= 0 {
+		return b[i+1:]
+	}
+	return b
+}
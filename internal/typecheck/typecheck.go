@@ -0,0 +1,49 @@
+// Package typecheck loads the packages read by the code generators.
+package typecheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Dir parses and type checks the package in dir, ignoring its tests and
+// the file previously generated into skip.
+func Dir(dir, skip string) (*types.Package, error) {
+	fset := token.NewFileSet()
+	skip, _ = filepath.Abs(skip)
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		path, _ := filepath.Abs(filepath.Join(dir, fi.Name()))
+		return path != skip && !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %s, found %d", dir, len(pkgs))
+	}
+
+	var files []*ast.File
+	var name string
+	for n, p := range pkgs {
+		name = n
+		for _, f := range p.Files {
+			files = append(files, f)
+		}
+	}
+
+	// Type errors are tolerated since the package may not compile until
+	// the code it refers to is generated.
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(error) {},
+	}
+	pkg, _ := conf.Check(name, fset, files, nil)
+	return pkg, nil
+}
@@ -0,0 +1,49 @@
+// Code generated by "binarygen -type=Header"; DO NOT EDIT.
+
+package mcp
+
+import (
+	"encoding/binary"
+
+	"github.com/samlitowitz/bnet-encoding/pkg/encoding/bnet"
+)
+
+// AppendBinary appends the wire encoding of x to b.
+func (x Header) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.Length), byte(x.Length>>8))
+	b = append(b, byte(x.MessageID))
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x Header) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *Header) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *Header) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *Header) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "Header", Field: "Length"}
+	}
+	x.Length = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 1 {
+		return off, &bnet.IndexOutOfRangeError{N: 1, Offset: int64(off), Struct: "Header", Field: "MessageID"}
+	}
+	x.MessageID = MessageID(data[off])
+	off++
+	return off, nil
+}
@@ -0,0 +1,84 @@
+// Package capture extracts MCP messages from pcap and pcapng capture
+// files. TCP streams are reassembled in memory, and a connection is
+// treated as MCP when its client opens with the protocol selector followed
+// by a MCP_STARTUP request. Connections whose start was not captured are
+// skipped.
+package capture
+
+import (
+	"io"
+	"net"
+	"time"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+	_ "github.com/samlitowitz/bnet-mcp/pkg/mcp/client"
+	_ "github.com/samlitowitz/bnet-mcp/pkg/mcp/server"
+)
+
+// Message is a MCP message extracted from a capture
+type Message struct {
+	// Time is the capture time of the packet completing the frame
+	Time      time.Time
+	Direction mcp.Direction
+	Client    *net.TCPAddr
+	Server    *net.TCPAddr
+	Frame     *mcp.Frame
+	// Message is the decoded message. It is a *mcp.RawMessage for message
+	// IDs without a registered type and for frames which failed to decode.
+	Message mcp.Message
+	// Err is the error decoding the frame, if any
+	Err error
+}
+
+// Reader extracts MCP messages from a capture file
+type Reader struct {
+	src   packetSource
+	conns map[connKey]*conn
+	queue []*Message
+}
+
+// NewReader returns a Reader extracting messages from the pcap or pcapng
+// capture read from r.
+func NewReader(r io.Reader) (*Reader, error) {
+	src, err := newPacketSource(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{src: src, conns: make(map[connKey]*conn)}, nil
+}
+
+// Next returns the next message in capture order. It returns io.EOF at
+// the end of the capture. Frames left incomplete at the end of the capture
+// are discarded.
+func (r *Reader) Next() (*Message, error) {
+	for len(r.queue) == 0 {
+		p, err := r.src.next()
+		if err != nil {
+			return nil, err
+		}
+		if seg, ok := decodeSegment(p); ok {
+			r.add(seg, p.time)
+		}
+	}
+
+	m := r.queue[0]
+	r.queue[0] = nil
+	r.queue = r.queue[1:]
+	return m, nil
+}
+
+// add passes seg to its connection, creating the connection if seg opens
+// a new one.
+func (r *Reader) add(seg *segment, t time.Time) {
+	k := newConnKey(seg.src, seg.dst)
+	c, ok := r.conns[k]
+	if !ok || seg.flags&(tcpSYN|tcpACK) == tcpSYN {
+		c = newConn(seg.src, seg.dst)
+		r.conns[k] = c
+	}
+
+	r.queue = c.add(seg, t, r.queue)
+	if c.done() {
+		delete(r.conns, k)
+	}
+}
@@ -0,0 +1,221 @@
+package capture
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/client"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/server"
+)
+
+var (
+	clientEnd = endpoint{ip: ip16("10.0.0.2"), port: 50123}
+	serverEnd = endpoint{ip: ip16("10.0.0.1"), port: 6112}
+	epoch     = time.Date(2019, 4, 26, 12, 0, 0, 0, time.UTC)
+)
+
+func ip16(s string) [16]byte {
+	var ip [16]byte
+	copy(ip[:], net.ParseIP(s).To16())
+	return ip
+}
+
+// testPacket is a TCP segment to be written to a capture
+type testPacket struct {
+	fromClient bool
+	seq        uint32 // relative to the initial sequence number
+	flags      uint8
+	payload    []byte
+}
+
+// ethernet returns the Ethernet frame carrying p.
+func (p testPacket) ethernet() []byte {
+	src, dst := clientEnd, serverEnd
+	isn := uint32(0xfffffff0) // wraps during the test
+	if !p.fromClient {
+		src, dst = dst, src
+		isn = 0x10000000
+	}
+
+	tcp := make([]byte, 20, 20+len(p.payload))
+	binary.BigEndian.PutUint16(tcp[0:], src.port)
+	binary.BigEndian.PutUint16(tcp[2:], dst.port)
+	binary.BigEndian.PutUint32(tcp[4:], isn+p.seq)
+	tcp[12] = 5 << 4
+	tcp[13] = p.flags
+	tcp = append(tcp, p.payload...)
+
+	ip := make([]byte, 20, 20+len(tcp))
+	ip[0] = 0x45
+	binary.BigEndian.PutUint16(ip[2:], uint16(20+len(tcp)))
+	ip[6] = 0x40 // don't fragment
+	ip[8] = 64
+	ip[9] = protocolTCP
+	copy(ip[12:], net.IP(src.ip[:]).To4())
+	copy(ip[16:], net.IP(dst.ip[:]).To4())
+	ip = append(ip, tcp...)
+
+	eth := make([]byte, 14, 14+len(ip))
+	binary.BigEndian.PutUint16(eth[12:], etherTypeIPv4)
+	return append(eth, ip...)
+}
+
+func writePcap(packets []testPacket) []byte {
+	var b bytes.Buffer
+	hdr := []uint32{pcapMagicMicro, 0x00040002, 0, 0, 65535, linkTypeEthernet}
+	binary.Write(&b, binary.LittleEndian, hdr)
+	for i, p := range packets {
+		data := p.ethernet()
+		t := epoch.Add(time.Duration(i) * time.Millisecond)
+		rec := []uint32{uint32(t.Unix()), uint32(t.Nanosecond() / 1000), uint32(len(data)), uint32(len(data))}
+		binary.Write(&b, binary.LittleEndian, rec)
+		b.Write(data)
+	}
+	return b.Bytes()
+}
+
+func writePcapng(packets []testPacket) []byte {
+	var b bytes.Buffer
+	block := func(typ uint32, body []byte) {
+		for len(body)%4 != 0 {
+			body = append(body, 0)
+		}
+		binary.Write(&b, binary.BigEndian, typ)
+		binary.Write(&b, binary.BigEndian, uint32(12+len(body)))
+		b.Write(body)
+		binary.Write(&b, binary.BigEndian, uint32(12+len(body)))
+	}
+
+	block(pcapngSectionHeader, []byte{0x1a, 0x2b, 0x3c, 0x4d, 0, 1, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	// Ethernet with nanosecond timestamps
+	block(pcapngInterfaceDesc, []byte{0, 1, 0, 0, 0, 0, 0xff, 0xff, 0, 9, 0, 1, 9, 0, 0, 0, 0, 0, 0, 0})
+	for i, p := range packets {
+		data := p.ethernet()
+		ts := uint64(epoch.Add(time.Duration(i) * time.Millisecond).UnixNano())
+		body := make([]byte, 20, 20+len(data))
+		binary.BigEndian.PutUint32(body[4:], uint32(ts>>32))
+		binary.BigEndian.PutUint32(body[8:], uint32(ts))
+		binary.BigEndian.PutUint32(body[12:], uint32(len(data)))
+		binary.BigEndian.PutUint32(body[16:], uint32(len(data)))
+		block(pcapngEnhancedPacket, append(body, data...))
+	}
+	return b.Bytes()
+}
+
+func marshal(t *testing.T, msgs ...mcp.Message) []byte {
+	t.Helper()
+	var b []byte
+	for _, m := range msgs {
+		var err error
+		if b, err = mcp.Append(b, m.ID(), m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return b
+}
+
+func readAll(t *testing.T, data []byte) []*Message {
+	t.Helper()
+	r, err := NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msgs []*Message
+	for {
+		m, err := r.Next()
+		if err == io.EOF {
+			return msgs
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		msgs = append(msgs, m)
+	}
+}
+
+func TestReader(t *testing.T) {
+	startup := &client.Startup{MCPCookie: 0x8c2b0f73, UniqueName: "Conan"}
+	charlogon := &client.CharLogon{CharacterName: "Conan"}
+	startupOK := &server.Startup{Result: server.StartupSuccess}
+	charlogonOK := &server.CharLogon{Result: server.CharLogonSuccess}
+	unknown := &mcp.RawMessage{MessageID: 0x0b, Dir: mcp.ServerToClient, Payload: []byte{0xaa}}
+
+	c2s := append([]byte{mcp.ProtocolSelector}, marshal(t, startup, charlogon)...)
+	s2c := marshal(t, startupOK, charlogonOK, unknown)
+	n := uint32(len(c2s))
+	split := n - 4 // inside the MCP_CHARLOGON frame
+
+	packets := []testPacket{
+		{fromClient: true, flags: tcpSYN},
+		{seq: 0, flags: tcpSYN | tcpACK},
+		{fromClient: true, seq: 1, flags: tcpACK, payload: c2s[:split-5]},
+		{seq: 1, flags: tcpACK, payload: s2c[:7]},
+		// Out of order, then a retransmission overlapping assembled data
+		{fromClient: true, seq: 1 + split, flags: tcpACK, payload: c2s[split:]},
+		{fromClient: true, seq: 1, flags: tcpACK, payload: c2s[:split]},
+		{seq: 8, flags: tcpACK, payload: s2c[7:]},
+		{fromClient: true, seq: 1 + n, flags: tcpFIN | tcpACK},
+		{seq: 1 + uint32(len(s2c)), flags: tcpFIN | tcpACK},
+	}
+
+	want := []struct {
+		packet int
+		dir    mcp.Direction
+		msg    mcp.Message
+	}{
+		{2, mcp.ClientToServer, startup},
+		{3, mcp.ServerToClient, startupOK},
+		{5, mcp.ClientToServer, charlogon},
+		{6, mcp.ServerToClient, charlogonOK},
+		{6, mcp.ServerToClient, unknown},
+	}
+
+	for name, data := range map[string][]byte{"pcap": writePcap(packets), "pcapng": writePcapng(packets)} {
+		got := readAll(t, data)
+		if len(got) != len(want) {
+			t.Fatalf("%s: got %d messages, want %d", name, len(got), len(want))
+		}
+		for i, w := range want {
+			m := got[i]
+			if m.Err != nil {
+				t.Errorf("%s: message %d: %s", name, i, m.Err)
+			}
+			if m.Direction != w.dir || !reflect.DeepEqual(m.Message, w.msg) {
+				t.Errorf("%s: message %d: got %s %#v, want %s %#v", name, i, m.Direction, m.Message, w.dir, w.msg)
+			}
+			if wt := epoch.Add(time.Duration(w.packet) * time.Millisecond); !m.Time.Equal(wt) {
+				t.Errorf("%s: message %d: time %s, want %s", name, i, m.Time, wt)
+			}
+			if m.Client.String() != "10.0.0.2:50123" || m.Server.String() != "10.0.0.1:6112" {
+				t.Errorf("%s: message %d: client %s, server %s", name, i, m.Client, m.Server)
+			}
+		}
+	}
+}
+
+func TestReaderIgnoresOtherProtocols(t *testing.T) {
+	packets := []testPacket{
+		{fromClient: true, flags: tcpSYN},
+		{seq: 0, flags: tcpSYN | tcpACK},
+		// A Battle.net chat connection also opens with 0x01
+		{fromClient: true, seq: 1, flags: tcpACK, payload: []byte{0x01, 0xff, 0x50, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{seq: 1, flags: tcpACK, payload: []byte{0x07, 0x00, 0x07, 0x00, 0x00, 0x00, 0x00}},
+	}
+
+	if got := readAll(t, writePcap(packets)); len(got) != 0 {
+		t.Errorf("got %d messages from a non-MCP connection", len(got))
+	}
+}
+
+func TestReaderFormatError(t *testing.T) {
+	_, err := NewReader(bytes.NewReader(make([]byte, 24)))
+	if _, ok := err.(*FormatError); !ok {
+		t.Errorf("got %v, want *FormatError", err)
+	}
+}
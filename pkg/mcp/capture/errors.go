@@ -0,0 +1,14 @@
+package capture
+
+import "fmt"
+
+// A FormatError occurs when a capture file is malformed. Offset is the
+// position in the file of the offending record.
+type FormatError struct {
+	Offset int64
+	Reason string
+}
+
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("capture: %s at offset %d", e.Reason, e.Offset)
+}
@@ -0,0 +1,307 @@
+package capture
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math/bits"
+	"time"
+)
+
+const (
+	pcapMagicMicro = 0xa1b2c3d4
+	pcapMagicNano  = 0xa1b23c4d
+
+	pcapngSectionHeader      = 0x0a0d0d0a
+	pcapngInterfaceDesc      = 0x00000001
+	pcapngPacket             = 0x00000002
+	pcapngSimplePacket       = 0x00000003
+	pcapngEnhancedPacket     = 0x00000006
+	pcapngByteOrderMagic     = 0x1a2b3c4d
+	pcapngOptionEnd          = 0
+	pcapngOptionTSResolution = 9
+
+	// maxBlockLength bounds the records read from a file so that a
+	// corrupt length cannot exhaust memory
+	maxBlockLength = 1 << 24
+)
+
+// packet is a captured link layer packet
+type packet struct {
+	time     time.Time
+	linkType uint32
+	data     []byte
+}
+
+// packetSource yields the packets of a capture file in file order
+type packetSource interface {
+	next() (*packet, error)
+}
+
+// newPacketSource detects the format of the capture in r.
+func newPacketSource(r io.Reader) (packetSource, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(4)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	if binary.LittleEndian.Uint32(magic) == pcapngSectionHeader {
+		return &pcapngSource{r: br}, nil
+	}
+	return newPcapSource(br)
+}
+
+// pcapSource reads the classic libpcap format
+type pcapSource struct {
+	r        io.Reader
+	order    binary.ByteOrder
+	nano     bool
+	linkType uint32
+	hdr      [16]byte
+	offset   int64
+}
+
+func newPcapSource(r io.Reader) (*pcapSource, error) {
+	var hdr [24]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	s := &pcapSource{r: r, offset: int64(len(hdr))}
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		switch order.Uint32(hdr[0:]) {
+		case pcapMagicMicro:
+			s.order = order
+		case pcapMagicNano:
+			s.order, s.nano = order, true
+		}
+	}
+	if s.order == nil {
+		return nil, &FormatError{Offset: 0, Reason: "unknown file format"}
+	}
+	s.linkType = s.order.Uint32(hdr[20:])
+	return s, nil
+}
+
+func (s *pcapSource) next() (*packet, error) {
+	if _, err := io.ReadFull(s.r, s.hdr[:]); err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, unexpectedEOF(err)
+	}
+
+	sec := s.order.Uint32(s.hdr[0:])
+	frac := s.order.Uint32(s.hdr[4:])
+	n := s.order.Uint32(s.hdr[8:])
+	if n > maxBlockLength {
+		return nil, &FormatError{Offset: s.offset, Reason: "packet record too large"}
+	}
+	s.offset += int64(len(s.hdr)) + int64(n)
+
+	p := &packet{linkType: s.linkType, data: make([]byte, n)}
+	if _, err := io.ReadFull(s.r, p.data); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if s.nano {
+		p.time = time.Unix(int64(sec), int64(frac)).UTC()
+	} else {
+		p.time = time.Unix(int64(sec), int64(frac)*int64(time.Microsecond)).UTC()
+	}
+	return p, nil
+}
+
+// pcapngInterface is an interface described in a pcapng section
+type pcapngInterface struct {
+	linkType uint32
+	// Timestamps are in units of 10^-exp seconds, or 2^-exp seconds if
+	// binary is set.
+	exp    uint
+	binary bool
+}
+
+// pcapngSource reads the pcapng format
+type pcapngSource struct {
+	r          io.Reader
+	order      binary.ByteOrder
+	interfaces []pcapngInterface
+	// offset is the file position of the next block and block that of
+	// the last one read.
+	offset int64
+	block  int64
+}
+
+func (s *pcapngSource) next() (*packet, error) {
+	for {
+		typ, body, err := s.readBlock()
+		if err != nil {
+			return nil, err
+		}
+
+		switch typ {
+		case pcapngInterfaceDesc:
+			if err := s.addInterface(body); err != nil {
+				return nil, err
+			}
+		case pcapngEnhancedPacket, pcapngPacket:
+			if len(body) < 20 {
+				return nil, &FormatError{Offset: s.block, Reason: "short packet block"}
+			}
+			id := s.order.Uint32(body[0:])
+			if typ == pcapngPacket {
+				id = uint32(s.order.Uint16(body[0:]))
+			}
+			if int(id) >= len(s.interfaces) {
+				return nil, &FormatError{Offset: s.block, Reason: "packet for undeclared interface"}
+			}
+			ts := uint64(s.order.Uint32(body[4:]))<<32 | uint64(s.order.Uint32(body[8:]))
+			n := s.order.Uint32(body[12:])
+			if uint64(n) > uint64(len(body)-20) {
+				return nil, &FormatError{Offset: s.block, Reason: "packet data exceeds block"}
+			}
+			iface := s.interfaces[id]
+			return &packet{
+				time:     iface.time(ts),
+				linkType: iface.linkType,
+				data:     body[20 : 20+n],
+			}, nil
+		case pcapngSimplePacket:
+			if len(s.interfaces) == 0 {
+				return nil, &FormatError{Offset: s.block, Reason: "packet for undeclared interface"}
+			}
+			if len(body) < 4 {
+				return nil, &FormatError{Offset: s.block, Reason: "short packet block"}
+			}
+			n := s.order.Uint32(body[0:])
+			if uint64(n) > uint64(len(body)-4) {
+				n = uint32(len(body) - 4)
+			}
+			return &packet{linkType: s.interfaces[0].linkType, data: body[4 : 4+n]}, nil
+		}
+	}
+}
+
+// readBlock returns the type and body of the next block. A section header
+// block resets the byte order and interfaces.
+func (s *pcapngSource) readBlock() (uint32, []byte, error) {
+	var hdr [12]byte
+	if _, err := io.ReadFull(s.r, hdr[:8]); err != nil {
+		if err == io.EOF {
+			return 0, nil, io.EOF
+		}
+		return 0, nil, unexpectedEOF(err)
+	}
+	offset := s.offset
+	s.block = offset
+
+	typ := binary.LittleEndian.Uint32(hdr[0:])
+	read := 8
+	if typ == pcapngSectionHeader {
+		if _, err := io.ReadFull(s.r, hdr[8:12]); err != nil {
+			return 0, nil, unexpectedEOF(err)
+		}
+		read = 12
+		switch {
+		case binary.LittleEndian.Uint32(hdr[8:]) == pcapngByteOrderMagic:
+			s.order = binary.LittleEndian
+		case binary.BigEndian.Uint32(hdr[8:]) == pcapngByteOrderMagic:
+			s.order = binary.BigEndian
+		default:
+			return 0, nil, &FormatError{Offset: offset, Reason: "bad byte-order magic"}
+		}
+		s.interfaces = nil
+	}
+	if s.order == nil {
+		return 0, nil, &FormatError{Offset: offset, Reason: "missing section header"}
+	}
+
+	typ = s.order.Uint32(hdr[0:])
+	length := s.order.Uint32(hdr[4:])
+	if length < 12 || length%4 != 0 || length > maxBlockLength || int(length) < read+4 {
+		return 0, nil, &FormatError{Offset: offset, Reason: "bad block length"}
+	}
+
+	rest := make([]byte, int(length)-read)
+	if _, err := io.ReadFull(s.r, rest); err != nil {
+		return 0, nil, unexpectedEOF(err)
+	}
+	s.offset += int64(length)
+
+	if trailer := s.order.Uint32(rest[len(rest)-4:]); trailer != length {
+		return 0, nil, &FormatError{Offset: offset, Reason: "block length mismatch"}
+	}
+	return typ, rest[:len(rest)-4], nil
+}
+
+func (s *pcapngSource) addInterface(body []byte) error {
+	if len(body) < 8 {
+		return &FormatError{Offset: s.block, Reason: "short interface block"}
+	}
+
+	iface := pcapngInterface{linkType: uint32(s.order.Uint16(body[0:])), exp: 6}
+	opts := body[8:]
+	for len(opts) >= 4 {
+		code := s.order.Uint16(opts[0:])
+		n := int(s.order.Uint16(opts[2:]))
+		size := 4 + (n+3)&^3
+		if code == pcapngOptionEnd || len(opts) < size {
+			break
+		}
+		if code == pcapngOptionTSResolution && n >= 1 {
+			res := opts[4]
+			iface.binary = res&0x80 != 0
+			iface.exp = uint(res & 0x7f)
+		}
+		opts = opts[size:]
+	}
+
+	s.interfaces = append(s.interfaces, iface)
+	return nil
+}
+
+// time converts a timestamp in the units of i to a time.
+func (i pcapngInterface) time(ts uint64) time.Time {
+	var sec, nsec uint64
+	switch {
+	case i.binary:
+		if i.exp >= 64 {
+			return time.Time{}
+		}
+		sec = ts >> i.exp
+		hi, lo := bits.Mul64(ts&(1<<i.exp-1), uint64(time.Second))
+		nsec = lo >> i.exp
+		if i.exp > 0 {
+			nsec |= hi << (64 - i.exp)
+		}
+	case i.exp <= 9:
+		unit := pow10(i.exp)
+		sec = ts / unit
+		nsec = ts % unit * pow10(9-i.exp)
+	case i.exp <= 19:
+		unit := pow10(i.exp)
+		sec = ts / unit
+		nsec = ts % unit / pow10(i.exp-9)
+	default:
+		return time.Time{}
+	}
+	return time.Unix(int64(sec), int64(nsec)).UTC()
+}
+
+func pow10(n uint) uint64 {
+	p := uint64(1)
+	for ; n > 0; n-- {
+		p *= 10
+	}
+	return p
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
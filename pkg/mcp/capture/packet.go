@@ -0,0 +1,220 @@
+package capture
+
+import (
+	"encoding/binary"
+	"net"
+)
+
+// Link layer types from https://www.tcpdump.org/linktypes.html
+const (
+	linkTypeNull     = 0
+	linkTypeEthernet = 1
+	linkTypeRaw      = 101
+	linkTypeLoop     = 108
+	linkTypeLinuxSLL = 113
+	linkTypeIPv4     = 228
+	linkTypeIPv6     = 229
+	linkTypeSLL2     = 276
+)
+
+const (
+	etherTypeIPv4 = 0x0800
+	etherTypeIPv6 = 0x86dd
+	etherTypeVLAN = 0x8100
+	etherTypeQinQ = 0x88a8
+
+	protocolTCP = 6
+
+	tcpFIN = 0x01
+	tcpSYN = 0x02
+	tcpRST = 0x04
+	tcpACK = 0x10
+)
+
+// endpoint is one end of a TCP connection
+type endpoint struct {
+	ip   [16]byte
+	port uint16
+}
+
+func (e endpoint) addr() *net.TCPAddr {
+	ip := net.IP(append([]byte(nil), e.ip[:]...))
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	return &net.TCPAddr{IP: ip, Port: int(e.port)}
+}
+
+// segment is the TCP segment carried by a packet
+type segment struct {
+	src, dst endpoint
+	seq      uint32
+	flags    uint8
+	payload  []byte
+}
+
+// decodeSegment returns the TCP segment carried by p. It reports false for
+// packets which are not TCP, are fragmented or are truncated.
+func decodeSegment(p *packet) (*segment, bool) {
+	ipv, data, ok := decodeLink(p.linkType, p.data)
+	if !ok {
+		return nil, false
+	}
+
+	s := &segment{}
+	switch ipv {
+	case 4:
+		data, ok = decodeIPv4(s, data)
+	case 6:
+		data, ok = decodeIPv6(s, data)
+	default:
+		return nil, false
+	}
+	if !ok || !decodeTCP(s, data) {
+		return nil, false
+	}
+	return s, true
+}
+
+// decodeLink returns the IP version and the IP packet carried by the link
+// layer frame data.
+func decodeLink(linkType uint32, data []byte) (int, []byte, bool) {
+	switch linkType {
+	case linkTypeNull, linkTypeLoop:
+		if len(data) < 4 {
+			return 0, nil, false
+		}
+		// The address family is in the byte order of the capturing host
+		// for DLT_NULL and big endian for DLT_LOOP.
+		family := binary.LittleEndian.Uint32(data)
+		if family > 0xffff {
+			family = binary.BigEndian.Uint32(data)
+		}
+		switch family {
+		case 2:
+			return 4, data[4:], true
+		case 10, 24, 28, 30:
+			return 6, data[4:], true
+		}
+		return 0, nil, false
+	case linkTypeEthernet:
+		if len(data) < 14 {
+			return 0, nil, false
+		}
+		etherType := binary.BigEndian.Uint16(data[12:])
+		data = data[14:]
+		for etherType == etherTypeVLAN || etherType == etherTypeQinQ {
+			if len(data) < 4 {
+				return 0, nil, false
+			}
+			etherType = binary.BigEndian.Uint16(data[2:])
+			data = data[4:]
+		}
+		return etherVersion(etherType, data)
+	case linkTypeLinuxSLL:
+		if len(data) < 16 {
+			return 0, nil, false
+		}
+		return etherVersion(binary.BigEndian.Uint16(data[14:]), data[16:])
+	case linkTypeSLL2:
+		if len(data) < 20 {
+			return 0, nil, false
+		}
+		return etherVersion(binary.BigEndian.Uint16(data[0:]), data[20:])
+	case linkTypeRaw, linkTypeIPv4, linkTypeIPv6:
+		if len(data) < 1 {
+			return 0, nil, false
+		}
+		return int(data[0] >> 4), data, true
+	}
+	return 0, nil, false
+}
+
+func etherVersion(etherType uint16, data []byte) (int, []byte, bool) {
+	switch etherType {
+	case etherTypeIPv4:
+		return 4, data, true
+	case etherTypeIPv6:
+		return 6, data, true
+	}
+	return 0, nil, false
+}
+
+// decodeIPv4 fills the addresses of s and returns the TCP segment carried
+// by the IPv4 packet data.
+func decodeIPv4(s *segment, data []byte) ([]byte, bool) {
+	if len(data) < 20 || data[0]>>4 != 4 {
+		return nil, false
+	}
+	ihl := int(data[0]&0x0f) * 4
+	total := int(binary.BigEndian.Uint16(data[2:]))
+	if ihl < 20 || total < ihl || total > len(data) {
+		return nil, false
+	}
+	if frag := binary.BigEndian.Uint16(data[6:]); frag&0x3fff != 0 {
+		// More fragments or a non-zero fragment offset
+		return nil, false
+	}
+	if data[9] != protocolTCP {
+		return nil, false
+	}
+
+	copy(s.src.ip[:], net.IP(data[12:16]).To16())
+	copy(s.dst.ip[:], net.IP(data[16:20]).To16())
+	return data[ihl:total], true
+}
+
+// decodeIPv6 fills the addresses of s and returns the TCP segment carried
+// by the IPv6 packet data.
+func decodeIPv6(s *segment, data []byte) ([]byte, bool) {
+	if len(data) < 40 || data[0]>>4 != 6 {
+		return nil, false
+	}
+	length := int(binary.BigEndian.Uint16(data[4:]))
+	if 40+length > len(data) {
+		return nil, false
+	}
+	copy(s.src.ip[:], data[8:24])
+	copy(s.dst.ip[:], data[24:40])
+
+	next := data[6]
+	data = data[40 : 40+length]
+	for {
+		switch next {
+		case protocolTCP:
+			return data, true
+		case 0, 43, 60:
+			// Hop-by-hop, routing and destination options
+			if len(data) < 8 {
+				return nil, false
+			}
+			n := (int(data[1]) + 1) * 8
+			if n > len(data) {
+				return nil, false
+			}
+			next, data = data[0], data[n:]
+		default:
+			// Fragments and other protocols
+			return nil, false
+		}
+	}
+}
+
+// decodeTCP fills the ports, sequence number, flags and payload of s from
+// the TCP segment data.
+func decodeTCP(s *segment, data []byte) bool {
+	if len(data) < 20 {
+		return false
+	}
+	offset := int(data[12]>>4) * 4
+	if offset < 20 || offset > len(data) {
+		return false
+	}
+
+	s.src.port = binary.BigEndian.Uint16(data[0:])
+	s.dst.port = binary.BigEndian.Uint16(data[2:])
+	s.seq = binary.BigEndian.Uint32(data[4:])
+	s.flags = data[13]
+	s.payload = data[offset:]
+	return true
+}
@@ -0,0 +1,243 @@
+package capture
+
+import (
+	"bytes"
+	"net"
+	"time"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+// maxPending bounds the out of order segments buffered per stream
+const maxPending = 1024
+
+type connState uint8
+
+const (
+	stateDetecting connState = iota
+	stateMCP
+	stateIgnored
+)
+
+// connKey identifies a connection independently of direction
+type connKey struct {
+	a, b endpoint
+}
+
+func newConnKey(src, dst endpoint) connKey {
+	if less(dst, src) {
+		src, dst = dst, src
+	}
+	return connKey{a: src, b: dst}
+}
+
+func less(a, b endpoint) bool {
+	if c := bytes.Compare(a.ip[:], b.ip[:]); c != 0 {
+		return c < 0
+	}
+	return a.port < b.port
+}
+
+// conn is a TCP connection. Data sent by ends[i] is assembled in
+// streams[i].
+type conn struct {
+	state   connState
+	ends    [2]endpoint
+	streams [2]stream
+	closed  [2]bool
+	reset   bool
+
+	// client is the index of the end which sent the protocol selector
+	client     int
+	clientAddr *net.TCPAddr
+	serverAddr *net.TCPAddr
+}
+
+func newConn(src, dst endpoint) *conn {
+	return &conn{ends: [2]endpoint{src, dst}}
+}
+
+// add assembles the payload of seg, appending the messages it completes
+// to out.
+func (c *conn) add(seg *segment, t time.Time, out []*Message) []*Message {
+	i := 0
+	if seg.src != c.ends[0] {
+		i = 1
+	}
+	s := &c.streams[i]
+
+	seq := seg.seq
+	if seg.flags&tcpSYN != 0 {
+		s.start(seq + 1)
+	}
+	if seg.flags&tcpRST != 0 {
+		c.reset = true
+	}
+	if seg.flags&tcpFIN != 0 {
+		c.closed[i] = true
+	}
+	if c.state == stateIgnored || len(seg.payload) == 0 {
+		return out
+	}
+
+	if !s.started {
+		s.start(seq)
+	}
+	s.insert(seq, seg.payload)
+
+	if c.state == stateDetecting {
+		c.detect()
+	}
+	if c.state != stateMCP {
+		return out
+	}
+	for i := range c.streams {
+		out = c.frames(i, t, out)
+	}
+	return out
+}
+
+// done reports whether the connection has ended.
+func (c *conn) done() bool {
+	return c.reset || c.closed[0] && c.closed[1]
+}
+
+// detect decides whether the connection carries MCP once the first
+// stream with data holds the protocol selector and a header.
+func (c *conn) detect() {
+	for i := range c.streams {
+		buf := c.streams[i].buf
+		if len(buf) == 0 {
+			continue
+		}
+		if buf[0] != mcp.ProtocolSelector {
+			c.ignore()
+			return
+		}
+		if len(buf) < 1+mcp.HeaderLength {
+			return
+		}
+
+		var h mcp.Header
+		if err := h.UnmarshalBinary(buf[1:]); err != nil || h.MessageID != mcp.McpStartup || h.Length < mcp.HeaderLength {
+			c.ignore()
+			return
+		}
+		c.state = stateMCP
+		c.client = i
+		c.clientAddr = c.ends[i].addr()
+		c.serverAddr = c.ends[1-i].addr()
+		c.streams[i].buf = buf[1:]
+		return
+	}
+}
+
+func (c *conn) ignore() {
+	c.state = stateIgnored
+	c.streams = [2]stream{}
+}
+
+// frames splits the complete frames off stream i, appending them to out.
+func (c *conn) frames(i int, t time.Time, out []*Message) []*Message {
+	s := &c.streams[i]
+	dir := mcp.ServerToClient
+	if i == c.client {
+		dir = mcp.ClientToServer
+	}
+
+	for !s.broken && len(s.buf) >= mcp.HeaderLength {
+		m := &Message{
+			Time:      t,
+			Direction: dir,
+			Client:    c.clientAddr,
+			Server:    c.serverAddr,
+		}
+
+		var h mcp.Header
+		if err := h.UnmarshalBinary(s.buf); err != nil || h.Length < mcp.HeaderLength {
+			// Without a usable length the rest of the stream cannot be
+			// split into frames.
+			m.Err = &mcp.InvalidLengthError{Length: int(h.Length)}
+			out = append(out, m)
+			s.broken = true
+			s.buf = nil
+			break
+		}
+		if len(s.buf) < int(h.Length) {
+			break
+		}
+
+		m.Frame = &mcp.Frame{
+			Header:  h,
+			Payload: append([]byte(nil), s.buf[mcp.HeaderLength:h.Length]...),
+		}
+		m.Message, m.Err = mcp.DecodeFrame(dir, m.Frame)
+		if _, ok := m.Err.(*mcp.UnknownMessageError); ok {
+			m.Err = nil
+		}
+		if m.Message == nil {
+			m.Message = &mcp.RawMessage{MessageID: h.MessageID, Dir: dir, Payload: m.Frame.Payload}
+		}
+		out = append(out, m)
+
+		s.buf = s.buf[h.Length:]
+	}
+	if len(s.buf) == 0 {
+		s.buf = nil
+	}
+	return out
+}
+
+// stream reassembles the data sent by one end of a connection
+type stream struct {
+	started bool
+	broken  bool
+	next    uint32
+	pending map[uint32][]byte
+	buf     []byte
+}
+
+func (s *stream) start(seq uint32) {
+	*s = stream{started: true, next: seq}
+}
+
+// insert adds the data starting at sequence number seq. Data already
+// assembled is dropped and data past a gap is held until the gap fills.
+func (s *stream) insert(seq uint32, data []byte) {
+	if s.broken {
+		return
+	}
+
+	if d := int32(seq - s.next); d > 0 {
+		if s.pending == nil {
+			s.pending = make(map[uint32][]byte)
+		}
+		if len(s.pending) < maxPending && len(data) > len(s.pending[seq]) {
+			s.pending[seq] = append([]byte(nil), data...)
+		}
+		return
+	}
+	s.append(seq, data)
+
+	for progress := true; progress; {
+		progress = false
+		for k, v := range s.pending {
+			if int32(k-s.next) <= 0 {
+				delete(s.pending, k)
+				s.append(k, v)
+				progress = true
+			}
+		}
+	}
+}
+
+// append adds data starting at or before the next expected sequence
+// number.
+func (s *stream) append(seq uint32, data []byte) {
+	skip := int(int32(s.next - seq))
+	if skip >= len(data) {
+		return
+	}
+	s.buf = append(s.buf, data[skip:]...)
+	s.next += uint32(len(data) - skip)
+}
@@ -0,0 +1,25 @@
+//go:generate $GOBIN/stringer -type=CharacterClass -trimprefix=Class
+package mcp
+
+// CharacterClass is the class of a character
+type CharacterClass uint32
+
+const (
+	ClassAmazon CharacterClass = iota
+	ClassSorceress
+	ClassNecromancer
+	ClassPaladin
+	ClassBarbarian
+	ClassDruid    // Expansion only
+	ClassAssassin // Expansion only
+)
+
+// Valid reports whether c is a known class.
+func (c CharacterClass) Valid() bool {
+	return c <= ClassAssassin
+}
+
+// Expansion reports whether c is only available to expansion characters.
+func (c CharacterClass) Expansion() bool {
+	return c == ClassDruid || c == ClassAssassin
+}
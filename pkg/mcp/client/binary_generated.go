@@ -0,0 +1,703 @@
+// Code generated by "binarygen -type=Startup,CharCreate,CreateGame,JoinGame,GameList,GameInfo,CharLogon,CharDelete,RequestLadderData,MOTD,CancelCreateGame,CharRank,CharList,CharUpgrade,CharList2"; DO NOT EDIT.
+
+package client
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/samlitowitz/bnet-encoding/pkg/encoding/bnet"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+// AppendBinary appends the wire encoding of x to b.
+func (x Startup) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.MCPCookie), byte(x.MCPCookie>>8), byte(x.MCPCookie>>16), byte(x.MCPCookie>>24))
+	b = append(b, byte(x.MCPStatus), byte(x.MCPStatus>>8), byte(x.MCPStatus>>16), byte(x.MCPStatus>>24))
+	for i1 := range x.Chunk1 {
+		b = append(b, byte(x.Chunk1[i1]), byte(x.Chunk1[i1]>>8), byte(x.Chunk1[i1]>>16), byte(x.Chunk1[i1]>>24))
+	}
+	for i2 := range x.Chunk2 {
+		b = append(b, byte(x.Chunk2[i2]), byte(x.Chunk2[i2]>>8), byte(x.Chunk2[i2]>>16), byte(x.Chunk2[i2]>>24))
+	}
+	b = append(b, x.UniqueName...)
+	b = append(b, 0x00)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x Startup) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *Startup) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *Startup) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *Startup) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "Startup", Field: "MCPCookie"}
+	}
+	x.MCPCookie = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "Startup", Field: "MCPStatus"}
+	}
+	x.MCPStatus = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+	for i3 := range x.Chunk1 {
+		if len(data)-off < 4 {
+			return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "Startup", Field: "Chunk1"}
+		}
+		x.Chunk1[i3] = binary.LittleEndian.Uint32(data[off:])
+		off += 4
+	}
+	for i4 := range x.Chunk2 {
+		if len(data)-off < 4 {
+			return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "Startup", Field: "Chunk2"}
+		}
+		x.Chunk2[i4] = binary.LittleEndian.Uint32(data[off:])
+		off += 4
+	}
+	n5 := bytes.IndexByte(data[off:], 0x00)
+	if n5 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "Startup", Field: "UniqueName"}
+	}
+	x.UniqueName = string(data[off : off+n5])
+	off += n5 + 1
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharCreate) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.Class), byte(x.Class>>8), byte(x.Class>>16), byte(x.Class>>24))
+	b = append(b, byte(x.Flags), byte(x.Flags>>8))
+	b = append(b, x.Name...)
+	b = append(b, 0x00)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharCreate) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharCreate) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharCreate) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharCreate) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CharCreate", Field: "Class"}
+	}
+	x.Class = mcp.CharacterClass(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "CharCreate", Field: "Flags"}
+	}
+	x.Flags = CharCreateFlags(binary.LittleEndian.Uint16(data[off:]))
+	off += 2
+	n6 := bytes.IndexByte(data[off:], 0x00)
+	if n6 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "CharCreate", Field: "Name"}
+	}
+	x.Name = string(data[off : off+n6])
+	off += n6 + 1
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CreateGame) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.RequestID), byte(x.RequestID>>8))
+	b = append(b, byte(x.Difficulty), byte(x.Difficulty>>8), byte(x.Difficulty>>16), byte(x.Difficulty>>24))
+	b = append(b, byte(x.Unknown))
+	b = append(b, byte(x.LevelRestriction))
+	b = append(b, byte(x.MaxPlayers))
+	b = append(b, x.Name...)
+	b = append(b, 0x00)
+	b = append(b, x.Password...)
+	b = append(b, 0x00)
+	b = append(b, x.Description...)
+	b = append(b, 0x00)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CreateGame) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CreateGame) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CreateGame) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CreateGame) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "CreateGame", Field: "RequestID"}
+	}
+	x.RequestID = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CreateGame", Field: "Difficulty"}
+	}
+	x.Difficulty = mcp.Difficulty(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	if len(data)-off < 1 {
+		return off, &bnet.IndexOutOfRangeError{N: 1, Offset: int64(off), Struct: "CreateGame", Field: "Unknown"}
+	}
+	x.Unknown = data[off]
+	off++
+	if len(data)-off < 1 {
+		return off, &bnet.IndexOutOfRangeError{N: 1, Offset: int64(off), Struct: "CreateGame", Field: "LevelRestriction"}
+	}
+	x.LevelRestriction = data[off]
+	off++
+	if len(data)-off < 1 {
+		return off, &bnet.IndexOutOfRangeError{N: 1, Offset: int64(off), Struct: "CreateGame", Field: "MaxPlayers"}
+	}
+	x.MaxPlayers = data[off]
+	off++
+	n7 := bytes.IndexByte(data[off:], 0x00)
+	if n7 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "CreateGame", Field: "Name"}
+	}
+	x.Name = string(data[off : off+n7])
+	off += n7 + 1
+	n8 := bytes.IndexByte(data[off:], 0x00)
+	if n8 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "CreateGame", Field: "Password"}
+	}
+	x.Password = string(data[off : off+n8])
+	off += n8 + 1
+	n9 := bytes.IndexByte(data[off:], 0x00)
+	if n9 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "CreateGame", Field: "Description"}
+	}
+	x.Description = string(data[off : off+n9])
+	off += n9 + 1
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x JoinGame) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.RequestID), byte(x.RequestID>>8))
+	b = append(b, x.Name...)
+	b = append(b, 0x00)
+	b = append(b, x.Password...)
+	b = append(b, 0x00)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x JoinGame) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *JoinGame) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *JoinGame) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *JoinGame) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "JoinGame", Field: "RequestID"}
+	}
+	x.RequestID = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	n10 := bytes.IndexByte(data[off:], 0x00)
+	if n10 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "JoinGame", Field: "Name"}
+	}
+	x.Name = string(data[off : off+n10])
+	off += n10 + 1
+	n11 := bytes.IndexByte(data[off:], 0x00)
+	if n11 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "JoinGame", Field: "Password"}
+	}
+	x.Password = string(data[off : off+n11])
+	off += n11 + 1
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x GameList) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.RequestID), byte(x.RequestID>>8))
+	b = append(b, byte(x.Unknown), byte(x.Unknown>>8), byte(x.Unknown>>16), byte(x.Unknown>>24))
+	b = append(b, x.Search...)
+	b = append(b, 0x00)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x GameList) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *GameList) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *GameList) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *GameList) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "GameList", Field: "RequestID"}
+	}
+	x.RequestID = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "GameList", Field: "Unknown"}
+	}
+	x.Unknown = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+	n12 := bytes.IndexByte(data[off:], 0x00)
+	if n12 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "GameList", Field: "Search"}
+	}
+	x.Search = string(data[off : off+n12])
+	off += n12 + 1
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x GameInfo) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.RequestID), byte(x.RequestID>>8))
+	b = append(b, x.Name...)
+	b = append(b, 0x00)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x GameInfo) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *GameInfo) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *GameInfo) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *GameInfo) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "GameInfo", Field: "RequestID"}
+	}
+	x.RequestID = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	n13 := bytes.IndexByte(data[off:], 0x00)
+	if n13 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "GameInfo", Field: "Name"}
+	}
+	x.Name = string(data[off : off+n13])
+	off += n13 + 1
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharLogon) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, x.CharacterName...)
+	b = append(b, 0x00)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharLogon) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharLogon) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharLogon) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharLogon) decodeBinary(data []byte, off int) (int, error) {
+	n14 := bytes.IndexByte(data[off:], 0x00)
+	if n14 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "CharLogon", Field: "CharacterName"}
+	}
+	x.CharacterName = string(data[off : off+n14])
+	off += n14 + 1
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharDelete) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.Unknown), byte(x.Unknown>>8))
+	b = append(b, x.CharacterName...)
+	b = append(b, 0x00)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharDelete) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharDelete) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharDelete) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharDelete) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "CharDelete", Field: "Unknown"}
+	}
+	x.Unknown = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	n15 := bytes.IndexByte(data[off:], 0x00)
+	if n15 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "CharDelete", Field: "CharacterName"}
+	}
+	x.CharacterName = string(data[off : off+n15])
+	off += n15 + 1
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x RequestLadderData) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.LadderType))
+	b = append(b, byte(x.StartingPosition), byte(x.StartingPosition>>8))
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x RequestLadderData) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *RequestLadderData) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *RequestLadderData) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *RequestLadderData) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 1 {
+		return off, &bnet.IndexOutOfRangeError{N: 1, Offset: int64(off), Struct: "RequestLadderData", Field: "LadderType"}
+	}
+	x.LadderType = data[off]
+	off++
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "RequestLadderData", Field: "StartingPosition"}
+	}
+	x.StartingPosition = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x MOTD) AppendBinary(b []byte) ([]byte, error) {
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x MOTD) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *MOTD) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *MOTD) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *MOTD) decodeBinary(data []byte, off int) (int, error) {
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CancelCreateGame) AppendBinary(b []byte) ([]byte, error) {
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CancelCreateGame) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CancelCreateGame) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CancelCreateGame) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CancelCreateGame) decodeBinary(data []byte, off int) (int, error) {
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharRank) AppendBinary(b []byte) ([]byte, error) {
+	if x.Hardcore {
+		b = append(b, 0x01, 0x00, 0x00, 0x00)
+	} else {
+		b = append(b, 0x00, 0x00, 0x00, 0x00)
+	}
+	if x.Expansion {
+		b = append(b, 0x01, 0x00, 0x00, 0x00)
+	} else {
+		b = append(b, 0x00, 0x00, 0x00, 0x00)
+	}
+	b = append(b, byte(x.Class), byte(x.Class>>8), byte(x.Class>>16), byte(x.Class>>24))
+	b = append(b, x.CharacterName...)
+	b = append(b, 0x00)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharRank) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharRank) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharRank) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharRank) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CharRank", Field: "Hardcore"}
+	}
+	switch binary.LittleEndian.Uint32(data[off:]) {
+	case 0:
+		x.Hardcore = false
+	case 1:
+		x.Hardcore = true
+	default:
+		return off, &bnet.InvalidValueError{Struct: "CharRank", Field: "Hardcore", Value: data[off : off+4]}
+	}
+	off += 4
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CharRank", Field: "Expansion"}
+	}
+	switch binary.LittleEndian.Uint32(data[off:]) {
+	case 0:
+		x.Expansion = false
+	case 1:
+		x.Expansion = true
+	default:
+		return off, &bnet.InvalidValueError{Struct: "CharRank", Field: "Expansion", Value: data[off : off+4]}
+	}
+	off += 4
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CharRank", Field: "Class"}
+	}
+	x.Class = mcp.CharacterClass(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	n16 := bytes.IndexByte(data[off:], 0x00)
+	if n16 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "CharRank", Field: "CharacterName"}
+	}
+	x.CharacterName = string(data[off : off+n16])
+	off += n16 + 1
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharList) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.RequestCount), byte(x.RequestCount>>8), byte(x.RequestCount>>16), byte(x.RequestCount>>24))
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharList) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharList) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharList) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharList) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CharList", Field: "RequestCount"}
+	}
+	x.RequestCount = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharUpgrade) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, x.CharacterName...)
+	b = append(b, 0x00)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharUpgrade) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharUpgrade) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharUpgrade) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharUpgrade) decodeBinary(data []byte, off int) (int, error) {
+	n17 := bytes.IndexByte(data[off:], 0x00)
+	if n17 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "CharUpgrade", Field: "CharacterName"}
+	}
+	x.CharacterName = string(data[off : off+n17])
+	off += n17 + 1
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharList2) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.RequestCount), byte(x.RequestCount>>8), byte(x.RequestCount>>16), byte(x.RequestCount>>24))
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharList2) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharList2) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharList2) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharList2) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CharList2", Field: "RequestCount"}
+	}
+	x.RequestCount = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+	return off, nil
+}
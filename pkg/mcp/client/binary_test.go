@@ -0,0 +1,55 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/samlitowitz/bnet-encoding/pkg/encoding/bnet"
+)
+
+var benchGameList = GameList{
+	RequestID: 0x0004,
+	Unknown:   0x00000000,
+	Search:    "baal",
+}
+
+func BenchmarkGameListUnmarshalReflect(b *testing.B) {
+	data, _ := benchGameList.MarshalBinary()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var g GameList
+		if err := bnet.Unmarshal(data, &g); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGameListUnmarshalBinary(b *testing.B) {
+	data, _ := benchGameList.MarshalBinary()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var g GameList
+		if err := g.UnmarshalBinary(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGameListMarshalReflect(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := bnet.Marshal(&benchGameList); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGameListAppendBinary(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		var err error
+		if buf, err = benchGameList.AppendBinary(buf[:0]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,5 +1,17 @@
 package client
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
 // CancelCreateGame is the structure of a MCP_CANCELCREATEGAME request
 type CancelCreateGame struct {
 }
+
+// ID returns the message ID of a MCP_CANCELCREATEGAME request
+func (CancelCreateGame) ID() mcp.MessageID {
+	return mcp.McpCancelGameCreate
+}
+
+// Direction returns the direction of a MCP_CANCELCREATEGAME request
+func (CancelCreateGame) Direction() mcp.Direction {
+	return mcp.ClientToServer
+}
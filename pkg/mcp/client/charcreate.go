@@ -1,8 +1,68 @@
 package client
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+// CharCreateFlags are the flags of a MCP_CHARCREATE request
+type CharCreateFlags uint16
+
+const (
+	CharCreateHardcore  CharCreateFlags = 0x04
+	CharCreateExpansion CharCreateFlags = 0x20
+	CharCreateLadder    CharCreateFlags = 0x40
+
+	charCreateFlagsMask = CharCreateHardcore | CharCreateExpansion | CharCreateLadder
+)
+
 // CharCreateRequest is structure of a MCP_CHARCREATE request
 type CharCreate struct {
-	Class uint32
-	Flags uint16
+	Class mcp.CharacterClass
+	Flags CharCreateFlags
 	Name  string
 }
+
+// NewCharCreate returns a MCP_CHARCREATE request for a character of class
+// with flags. It returns an error if class is unknown, flags contains
+// unknown bits or class requires the expansion flag.
+func NewCharCreate(name string, class mcp.CharacterClass, flags CharCreateFlags) (*CharCreate, error) {
+	if _, err := validateClassFlags(class, flags); err != nil {
+		return nil, err
+	}
+
+	return &CharCreate{Class: class, Flags: flags, Name: name}, nil
+}
+
+// ID returns the message ID of a MCP_CHARCREATE request
+func (CharCreate) ID() mcp.MessageID {
+	return mcp.McpCharCreate
+}
+
+// Direction returns the direction of a MCP_CHARCREATE request
+func (CharCreate) Direction() mcp.Direction {
+	return mcp.ClientToServer
+}
+
+// Validate returns an error if c violates the constraints of a MCP_CHARCREATE request.
+func (c CharCreate) Validate() error {
+	if field, err := validateClassFlags(c.Class, c.Flags); err != nil {
+		return &mcp.ValidationError{MessageID: c.ID(), Field: field, Err: err}
+	}
+	if err := mcp.ValidateCharacterName(c.Name); err != nil {
+		return &mcp.ValidationError{MessageID: c.ID(), Field: "Name", Err: err}
+	}
+	return nil
+}
+
+// validateClassFlags returns the offending field and error if class and
+// flags cannot be combined.
+func validateClassFlags(class mcp.CharacterClass, flags CharCreateFlags) (string, error) {
+	if !class.Valid() {
+		return "Class", ErrInvalidClass
+	}
+	if flags&^charCreateFlagsMask != 0 {
+		return "Flags", ErrInvalidFlags
+	}
+	if class.Expansion() && flags&CharCreateExpansion == 0 {
+		return "Flags", ErrExpansionClass
+	}
+	return "", nil
+}
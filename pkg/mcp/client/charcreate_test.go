@@ -0,0 +1,84 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+func TestNewCharCreate(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		class mcp.CharacterClass
+		flags CharCreateFlags
+		err   error
+	}{
+		{"classic", mcp.ClassAmazon, 0, nil},
+		{"hardcore ladder expansion", mcp.ClassDruid, CharCreateHardcore | CharCreateLadder | CharCreateExpansion, nil},
+		{"druid without expansion", mcp.ClassDruid, 0, ErrExpansionClass},
+		{"assassin without expansion", mcp.ClassAssassin, CharCreateHardcore | CharCreateLadder, ErrExpansionClass},
+		{"unknown flag", mcp.ClassPaladin, CharCreateExpansion | 0x01, ErrInvalidFlags},
+		{"high flag", mcp.ClassPaladin, 0x8000, ErrInvalidFlags},
+		{"class above assassin", mcp.ClassAssassin + 1, CharCreateExpansion, ErrInvalidClass},
+	} {
+		c, err := NewCharCreate("Conan", tt.class, tt.flags)
+		if err != tt.err {
+			t.Errorf("%s: got error %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if err != nil {
+			if c != nil {
+				t.Errorf("%s: got %#v with error", tt.name, c)
+			}
+			continue
+		}
+		if c.Class != tt.class || c.Flags != tt.flags || c.Name != "Conan" {
+			t.Errorf("%s: got %#v", tt.name, c)
+		}
+	}
+}
+
+func TestCharCreateEncoding(t *testing.T) {
+	c, err := NewCharCreate("Conan", mcp.ClassAssassin, CharCreateHardcore|CharCreateLadder|CharCreateExpansion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{
+		0x06, 0x00, 0x00, 0x00, // Class
+		0x64, 0x00, // Flags
+		'C', 'o', 'n', 'a', 'n', 0x00,
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got % x, want % x", got, want)
+	}
+
+	var back CharCreate
+	if err := back.UnmarshalBinary(got); err != nil {
+		t.Fatal(err)
+	}
+	if back != *c {
+		t.Errorf("decoded %#v, want %#v", back, *c)
+	}
+}
+
+func TestCharCreateValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		msg   CharCreate
+		field string
+		err   error
+	}{
+		{"valid", CharCreate{Class: mcp.ClassDruid, Flags: CharCreateExpansion, Name: "Xe-na"}, "", nil},
+		{"class", CharCreate{Class: 0x07, Name: "Conan"}, "Class", ErrInvalidClass},
+		{"flags", CharCreate{Class: mcp.ClassAmazon, Flags: 0x02, Name: "Conan"}, "Flags", ErrInvalidFlags},
+		{"expansion class", CharCreate{Class: mcp.ClassAssassin, Name: "Conan"}, "Flags", ErrExpansionClass},
+		{"name", CharCreate{Class: mcp.ClassAmazon, Name: "C"}, "Name", mcp.ErrCharacterNameLength},
+	} {
+		wantValidationError(t, tt.name, tt.msg.Validate(), tt.field, tt.err)
+	}
+}
@@ -1,7 +1,27 @@
 package client
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
 // CharDeleteRequest is structure of a MCP_CHARDELETE request
 type CharDelete struct {
 	Unknown       uint16 // Cookie?
 	CharacterName string
 }
+
+// ID returns the message ID of a MCP_CHARDELETE request
+func (CharDelete) ID() mcp.MessageID {
+	return mcp.McpCharDelete
+}
+
+// Direction returns the direction of a MCP_CHARDELETE request
+func (CharDelete) Direction() mcp.Direction {
+	return mcp.ClientToServer
+}
+
+// Validate returns an error if c violates the constraints of a MCP_CHARDELETE request.
+func (c CharDelete) Validate() error {
+	if err := mcp.ValidateCharacterName(c.CharacterName); err != nil {
+		return &mcp.ValidationError{MessageID: c.ID(), Field: "CharacterName", Err: err}
+	}
+	return nil
+}
@@ -1,6 +1,26 @@
 package client
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
 // CharListRequest is structure of a MCP_CHARLIST request
 type CharList struct {
 	RequestCount uint32
 }
+
+// ID returns the message ID of a MCP_CHARLIST request
+func (CharList) ID() mcp.MessageID {
+	return mcp.McpCharList
+}
+
+// Direction returns the direction of a MCP_CHARLIST request
+func (CharList) Direction() mcp.Direction {
+	return mcp.ClientToServer
+}
+
+// Validate returns an error if c violates the constraints of a MCP_CHARLIST request.
+func (c CharList) Validate() error {
+	if c.RequestCount > MaxRequestCount {
+		return &mcp.ValidationError{MessageID: c.ID(), Field: "RequestCount", Err: ErrRequestCount}
+	}
+	return nil
+}
@@ -1,6 +1,30 @@
 package client
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+const (
+	MaxRequestCount = 8
+)
+
 // CharList2Request is structure of a MCP_CHARLIST2 request
 type CharList2 struct {
 	RequestCount uint32 // Max 8
 }
+
+// ID returns the message ID of a MCP_CHARLIST2 request
+func (CharList2) ID() mcp.MessageID {
+	return mcp.McpCharList2
+}
+
+// Direction returns the direction of a MCP_CHARLIST2 request
+func (CharList2) Direction() mcp.Direction {
+	return mcp.ClientToServer
+}
+
+// Validate returns an error if c violates the constraints of a MCP_CHARLIST2 request.
+func (c CharList2) Validate() error {
+	if c.RequestCount > MaxRequestCount {
+		return &mcp.ValidationError{MessageID: c.ID(), Field: "RequestCount", Err: ErrRequestCount}
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package client
+
+import "testing"
+
+func TestCharListValidate(t *testing.T) {
+	for _, tt := range []struct {
+		count uint32
+		field string
+	}{
+		{0, ""},
+		{MaxRequestCount, ""},
+		{MaxRequestCount + 1, "RequestCount"},
+		{0xffffffff, "RequestCount"},
+	} {
+		wantValidationError(t, "CharList", CharList{RequestCount: tt.count}.Validate(), tt.field, ErrRequestCount)
+		wantValidationError(t, "CharList2", CharList2{RequestCount: tt.count}.Validate(), tt.field, ErrRequestCount)
+	}
+}
@@ -1,6 +1,26 @@
 package client
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
 // CharLogonRequest is structure of a MCP_CHARLOGON request
 type CharLogon struct {
 	CharacterName string
 }
+
+// ID returns the message ID of a MCP_CHARLOGON request
+func (CharLogon) ID() mcp.MessageID {
+	return mcp.McpCharLogon
+}
+
+// Direction returns the direction of a MCP_CHARLOGON request
+func (CharLogon) Direction() mcp.Direction {
+	return mcp.ClientToServer
+}
+
+// Validate returns an error if c violates the constraints of a MCP_CHARLOGON request.
+func (c CharLogon) Validate() error {
+	if err := mcp.ValidateCharacterName(c.CharacterName); err != nil {
+		return &mcp.ValidationError{MessageID: c.ID(), Field: "CharacterName", Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package client
+
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+// CharRankRequest is the structure of a MCP_CHARRANK request
+type CharRank struct {
+	Hardcore      bool `bnet:"size-uint32"`
+	Expansion     bool `bnet:"size-uint32"`
+	Class         mcp.CharacterClass
+	CharacterName string
+}
+
+// ID returns the message ID of a MCP_CHARRANK request
+func (CharRank) ID() mcp.MessageID {
+	return mcp.McpCharRank
+}
+
+// Direction returns the direction of a MCP_CHARRANK request
+func (CharRank) Direction() mcp.Direction {
+	return mcp.ClientToServer
+}
+
+// Validate returns an error if c violates the constraints of a MCP_CHARRANK request.
+func (c CharRank) Validate() error {
+	if !c.Class.Valid() {
+		return &mcp.ValidationError{MessageID: c.ID(), Field: "Class", Err: ErrInvalidClass}
+	}
+	if err := mcp.ValidateCharacterName(c.CharacterName); err != nil {
+		return &mcp.ValidationError{MessageID: c.ID(), Field: "CharacterName", Err: err}
+	}
+	return nil
+}
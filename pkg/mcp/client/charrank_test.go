@@ -0,0 +1,112 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+func TestCharRankRoundTrip(t *testing.T) {
+	for _, tt := range []struct {
+		msg  *CharRank
+		want []byte
+	}{
+		{
+			&CharRank{Hardcore: true, Expansion: true, Class: mcp.ClassBarbarian, CharacterName: "Conan"},
+			[]byte{
+				0x15, 0x00, 0x16,
+				0x01, 0x00, 0x00, 0x00,
+				0x01, 0x00, 0x00, 0x00,
+				0x04, 0x00, 0x00, 0x00,
+				'C', 'o', 'n', 'a', 'n', 0x00,
+			},
+		},
+		{
+			&CharRank{Class: mcp.ClassSorceress, CharacterName: "Xena"},
+			[]byte{
+				0x14, 0x00, 0x16,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+				0x01, 0x00, 0x00, 0x00,
+				'X', 'e', 'n', 'a', 0x00,
+			},
+		},
+	} {
+		got, err := mcp.MarshalMessage(tt.msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("got % x, want % x", got, tt.want)
+		}
+
+		msg, err := mcp.Decode(mcp.ClientToServer, tt.want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(msg, tt.msg) {
+			t.Errorf("decoded %#v, want %#v", msg, tt.msg)
+		}
+	}
+}
+
+func TestCharRankInvalidBool(t *testing.T) {
+	frame := []byte{
+		0x14, 0x00, 0x16,
+		0x02, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+		0x01, 0x00, 0x00, 0x00,
+		'X', 'e', 'n', 'a', 0x00,
+	}
+	if _, err := mcp.Decode(mcp.ClientToServer, frame); err == nil {
+		t.Error("decoded a Hardcore value of 2")
+	}
+}
+
+func TestCharUpgradeRoundTrip(t *testing.T) {
+	msg := &CharUpgrade{CharacterName: "Xena"}
+	want := []byte{0x08, 0x00, 0x18, 'X', 'e', 'n', 'a', 0x00}
+
+	got, err := mcp.MarshalMessage(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got % x, want % x", got, want)
+	}
+
+	decoded, err := mcp.Decode(mcp.ClientToServer, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, msg) {
+		t.Errorf("decoded %#v, want %#v", decoded, msg)
+	}
+}
+
+// TestCharacterNameValidate covers the requests naming a character.
+func TestCharacterNameValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		field string
+		err   error
+	}{
+		{"Conan", "", nil},
+		{"C", "CharacterName", mcp.ErrCharacterNameLength},
+		{"Conan2", "CharacterName", mcp.ErrCharacterNameCharacters},
+	} {
+		for _, msg := range []mcp.Validator{
+			CharLogon{CharacterName: tt.name},
+			CharDelete{CharacterName: tt.name},
+			CharUpgrade{CharacterName: tt.name},
+			CharRank{Class: mcp.ClassAmazon, CharacterName: tt.name},
+		} {
+			wantValidationError(t, fmt.Sprintf("%T %q", msg, tt.name), msg.Validate(), tt.field, tt.err)
+		}
+	}
+
+	wantValidationError(t, "CharRank class", CharRank{Class: 0x07, CharacterName: "Conan"}.Validate(), "Class", ErrInvalidClass)
+}
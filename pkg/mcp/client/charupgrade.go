@@ -0,0 +1,26 @@
+package client
+
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+// CharUpgradeRequest is the structure of a MCP_CHARUPGRADE request
+type CharUpgrade struct {
+	CharacterName string
+}
+
+// ID returns the message ID of a MCP_CHARUPGRADE request
+func (CharUpgrade) ID() mcp.MessageID {
+	return mcp.McpCharUpgrade
+}
+
+// Direction returns the direction of a MCP_CHARUPGRADE request
+func (CharUpgrade) Direction() mcp.Direction {
+	return mcp.ClientToServer
+}
+
+// Validate returns an error if c violates the constraints of a MCP_CHARUPGRADE request.
+func (c CharUpgrade) Validate() error {
+	if err := mcp.ValidateCharacterName(c.CharacterName); err != nil {
+		return &mcp.ValidationError{MessageID: c.ID(), Field: "CharacterName", Err: err}
+	}
+	return nil
+}
@@ -1,13 +1,118 @@
 package client
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+const (
+	MaxPlayers               = 8
+	MaxLevelDifference       = 99
+	MaxGameNameLength        = 15
+	MaxGamePasswordLength    = 15
+	MaxGameDescriptionLength = 31
+
+	noLevelRestriction = 0xff
+)
+
+// GameOptions are the settings of a game created by a MCP_CREATEGAME request
+type GameOptions struct {
+	Difficulty      mcp.Difficulty
+	MaxPlayers      uint8 // 1-8
+	LevelRestricted bool
+	LevelDifference uint8 // Ignored unless LevelRestricted
+}
+
+// Validate returns an error if o cannot be sent in a MCP_CREATEGAME request.
+func (o GameOptions) Validate() error {
+	if !o.Difficulty.Valid() {
+		return ErrInvalidDifficulty
+	}
+	if o.MaxPlayers < 1 || o.MaxPlayers > MaxPlayers {
+		return ErrInvalidMaxPlayers
+	}
+	if o.LevelRestricted && o.LevelDifference > MaxLevelDifference {
+		return ErrInvalidLevelDifference
+	}
+	return nil
+}
+
 // CreateGameRequest is structure of a MCP_CREATEGAME request
 type CreateGame struct {
 	RequestID        uint16
-	Difficulty       uint32
-	Unknown          uint8
-	LevelRestriction uint8
+	Difficulty       mcp.Difficulty
+	Unknown          uint8 // Always 0x01
+	LevelRestriction uint8 // Level difference, 0xff if unrestricted
 	MaxPlayers       uint8
 	Name             string
 	Password         string
 	Description      string
 }
+
+// NewCreateGame returns a MCP_CREATEGAME request creating a game with
+// opts. It returns an error if opts is invalid.
+func NewCreateGame(requestID uint16, name, password, description string, opts GameOptions) (*CreateGame, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	c := &CreateGame{
+		RequestID:        requestID,
+		Difficulty:       opts.Difficulty,
+		Unknown:          0x01,
+		LevelRestriction: noLevelRestriction,
+		MaxPlayers:       opts.MaxPlayers,
+		Name:             name,
+		Password:         password,
+		Description:      description,
+	}
+	if opts.LevelRestricted {
+		c.LevelRestriction = opts.LevelDifference
+	}
+	return c, nil
+}
+
+// Options returns the settings of the game requested by c. It returns an
+// error if they are invalid.
+func (c CreateGame) Options() (GameOptions, error) {
+	opts := GameOptions{
+		Difficulty: c.Difficulty,
+		MaxPlayers: c.MaxPlayers,
+	}
+	if c.LevelRestriction != noLevelRestriction {
+		opts.LevelRestricted = true
+		opts.LevelDifference = c.LevelRestriction
+	}
+	return opts, opts.Validate()
+}
+
+// ID returns the message ID of a MCP_CREATEGAME request
+func (CreateGame) ID() mcp.MessageID {
+	return mcp.McpCreateGame
+}
+
+// Direction returns the direction of a MCP_CREATEGAME request
+func (CreateGame) Direction() mcp.Direction {
+	return mcp.ClientToServer
+}
+
+// Validate returns an error if c violates the constraints of a MCP_CREATEGAME request.
+func (c CreateGame) Validate() error {
+	if _, err := c.Options(); err != nil {
+		field := "Difficulty"
+		switch err {
+		case ErrInvalidMaxPlayers:
+			field = "MaxPlayers"
+		case ErrInvalidLevelDifference:
+			field = "LevelRestriction"
+		}
+		return &mcp.ValidationError{MessageID: c.ID(), Field: field, Err: err}
+	}
+	if c.Name == "" || len(c.Name) > MaxGameNameLength {
+		return &mcp.ValidationError{MessageID: c.ID(), Field: "Name", Err: ErrGameNameLength}
+	}
+	if len(c.Password) > MaxGamePasswordLength {
+		return &mcp.ValidationError{MessageID: c.ID(), Field: "Password", Err: ErrGamePasswordLength}
+	}
+	if len(c.Description) > MaxGameDescriptionLength {
+		return &mcp.ValidationError{MessageID: c.ID(), Field: "Description", Err: ErrGameDescriptionLength}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+func TestNewCreateGame(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		opts             GameOptions
+		levelRestriction uint8
+		err              error
+	}{
+		{"unrestricted", GameOptions{Difficulty: mcp.DifficultyNormal, MaxPlayers: 1}, 0xff, nil},
+		{"unrestricted ignores difference", GameOptions{Difficulty: mcp.DifficultyNormal, MaxPlayers: 8, LevelDifference: 200}, 0xff, nil},
+		{"restricted", GameOptions{Difficulty: mcp.DifficultyHell, MaxPlayers: 8, LevelRestricted: true, LevelDifference: 10}, 10, nil},
+		{"restricted to same level", GameOptions{Difficulty: mcp.DifficultyNightmare, MaxPlayers: 4, LevelRestricted: true}, 0, nil},
+		{"invalid difficulty", GameOptions{Difficulty: 0x3000, MaxPlayers: 8}, 0, ErrInvalidDifficulty},
+		{"no players", GameOptions{Difficulty: mcp.DifficultyHell}, 0, ErrInvalidMaxPlayers},
+		{"too many players", GameOptions{Difficulty: mcp.DifficultyHell, MaxPlayers: 9}, 0, ErrInvalidMaxPlayers},
+		{"level difference", GameOptions{Difficulty: mcp.DifficultyHell, MaxPlayers: 8, LevelRestricted: true, LevelDifference: 100}, 0, ErrInvalidLevelDifference},
+	} {
+		c, err := NewCreateGame(0x0002, "baal-run-42", "", "", tt.opts)
+		if err != tt.err {
+			t.Errorf("%s: got error %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if c.LevelRestriction != tt.levelRestriction || c.Difficulty != tt.opts.Difficulty || c.MaxPlayers != tt.opts.MaxPlayers || c.Unknown != 0x01 {
+			t.Errorf("%s: got %#v", tt.name, c)
+		}
+	}
+}
+
+func TestCreateGameOptions(t *testing.T) {
+	for _, tt := range []struct {
+		levelRestriction uint8
+		want             GameOptions
+	}{
+		{0xff, GameOptions{Difficulty: mcp.DifficultyHell, MaxPlayers: 8}},
+		{0x00, GameOptions{Difficulty: mcp.DifficultyHell, MaxPlayers: 8, LevelRestricted: true}},
+		{0x0a, GameOptions{Difficulty: mcp.DifficultyHell, MaxPlayers: 8, LevelRestricted: true, LevelDifference: 10}},
+	} {
+		c := CreateGame{Difficulty: mcp.DifficultyHell, LevelRestriction: tt.levelRestriction, MaxPlayers: 8}
+		got, err := c.Options()
+		if err != nil {
+			t.Errorf("0x%02x: %s", tt.levelRestriction, err)
+		}
+		if got != tt.want {
+			t.Errorf("0x%02x: got %+v, want %+v", tt.levelRestriction, got, tt.want)
+		}
+	}
+
+	if _, err := (CreateGame{Difficulty: 0x0001, LevelRestriction: 0xff, MaxPlayers: 8}).Options(); err != ErrInvalidDifficulty {
+		t.Errorf("got %v, want %v", err, ErrInvalidDifficulty)
+	}
+}
+
+// TestCreateGameRoundTrip sends a hell game for 8 players within 10 levels
+// of its creator.
+func TestCreateGameRoundTrip(t *testing.T) {
+	opts := GameOptions{Difficulty: mcp.DifficultyHell, MaxPlayers: 8, LevelRestricted: true, LevelDifference: 10}
+	c, err := NewCreateGame(0x0002, "baal-run-42", "pw", "fast runs", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{
+		0x02, 0x00, // RequestID
+		0x00, 0x20, 0x00, 0x00, // Difficulty
+		0x01, // Unknown
+		0x0a, // LevelRestriction
+		0x08, // MaxPlayers
+	}
+	want = append(want, "baal-run-42\x00pw\x00fast runs\x00"...)
+	if !bytes.Equal(got, want) {
+		t.Errorf("got % x, want % x", got, want)
+	}
+
+	var back CreateGame
+	if err := back.UnmarshalBinary(got); err != nil {
+		t.Fatal(err)
+	}
+	if back != *c {
+		t.Errorf("decoded %#v, want %#v", back, *c)
+	}
+	if o, err := back.Options(); err != nil || o != opts {
+		t.Errorf("options %+v, %v, want %+v", o, err, opts)
+	}
+}
+
+func TestCreateGameValidate(t *testing.T) {
+	valid := CreateGame{
+		Difficulty:       mcp.DifficultyHell,
+		Unknown:          0x01,
+		LevelRestriction: 0xff,
+		MaxPlayers:       8,
+		Name:             "baal-run-42",
+		Password:         "pw",
+		Description:      "fast runs",
+	}
+	for _, tt := range []struct {
+		name  string
+		edit  func(*CreateGame)
+		field string
+		err   error
+	}{
+		{"valid", func(c *CreateGame) {}, "", nil},
+		{"longest", func(c *CreateGame) {
+			c.Name = strings.Repeat("n", MaxGameNameLength)
+			c.Password = strings.Repeat("p", MaxGamePasswordLength)
+			c.Description = strings.Repeat("d", MaxGameDescriptionLength)
+			c.LevelRestriction = MaxLevelDifference
+		}, "", nil},
+		{"difficulty", func(c *CreateGame) { c.Difficulty = 0x0800 }, "Difficulty", ErrInvalidDifficulty},
+		{"no players", func(c *CreateGame) { c.MaxPlayers = 0 }, "MaxPlayers", ErrInvalidMaxPlayers},
+		{"too many players", func(c *CreateGame) { c.MaxPlayers = 9 }, "MaxPlayers", ErrInvalidMaxPlayers},
+		{"level difference", func(c *CreateGame) { c.LevelRestriction = 100 }, "LevelRestriction", ErrInvalidLevelDifference},
+		{"empty name", func(c *CreateGame) { c.Name = "" }, "Name", ErrGameNameLength},
+		{"long name", func(c *CreateGame) { c.Name = strings.Repeat("n", MaxGameNameLength+1) }, "Name", ErrGameNameLength},
+		{"long password", func(c *CreateGame) { c.Password = strings.Repeat("p", MaxGamePasswordLength+1) }, "Password", ErrGamePasswordLength},
+		{"long description", func(c *CreateGame) { c.Description = strings.Repeat("d", MaxGameDescriptionLength+1) }, "Description", ErrGameDescriptionLength},
+	} {
+		c := valid
+		tt.edit(&c)
+		wantValidationError(t, tt.name, c.Validate(), tt.field, tt.err)
+	}
+}
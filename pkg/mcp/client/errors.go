@@ -0,0 +1,17 @@
+package client
+
+import "errors"
+
+// Errors returned when constructing or validating requests.
+var (
+	ErrInvalidClass           = errors.New("mcp: invalid character class")
+	ErrInvalidFlags           = errors.New("mcp: invalid character flags")
+	ErrExpansionClass         = errors.New("mcp: character class requires expansion")
+	ErrInvalidDifficulty      = errors.New("mcp: invalid difficulty")
+	ErrInvalidMaxPlayers      = errors.New("mcp: max players must be between 1 and 8")
+	ErrInvalidLevelDifference = errors.New("mcp: level difference must be at most 99")
+	ErrGameNameLength         = errors.New("mcp: game name must be between 1 and 15 characters")
+	ErrGamePasswordLength     = errors.New("mcp: game password must be at most 15 characters")
+	ErrGameDescriptionLength  = errors.New("mcp: game description must be at most 31 characters")
+	ErrRequestCount           = errors.New("mcp: request count must be at most 8")
+)
@@ -1,7 +1,27 @@
 package client
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
 // GameInfoRequest is structure of a MCP_GAMEINFO request
 type GameInfo struct {
 	RequestID uint16
 	Name      string
 }
+
+// ID returns the message ID of a MCP_GAMEINFO request
+func (GameInfo) ID() mcp.MessageID {
+	return mcp.McpGameInfo
+}
+
+// Direction returns the direction of a MCP_GAMEINFO request
+func (GameInfo) Direction() mcp.Direction {
+	return mcp.ClientToServer
+}
+
+// Validate returns an error if g violates the constraints of a MCP_GAMEINFO request.
+func (g GameInfo) Validate() error {
+	if g.Name == "" || len(g.Name) > MaxGameNameLength {
+		return &mcp.ValidationError{MessageID: g.ID(), Field: "Name", Err: ErrGameNameLength}
+	}
+	return nil
+}
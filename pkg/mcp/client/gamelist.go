@@ -1,8 +1,28 @@
 package client
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
 // GameListRequest is structure of a MCP_GAMELIST request
 type GameList struct {
 	RequestID uint16
 	Unknown   uint32
 	Search    string
 }
+
+// ID returns the message ID of a MCP_GAMELIST request
+func (GameList) ID() mcp.MessageID {
+	return mcp.McpGameList
+}
+
+// Direction returns the direction of a MCP_GAMELIST request
+func (GameList) Direction() mcp.Direction {
+	return mcp.ClientToServer
+}
+
+// Validate returns an error if g violates the constraints of a MCP_GAMELIST request.
+func (g GameList) Validate() error {
+	if len(g.Search) > MaxGameNameLength {
+		return &mcp.ValidationError{MessageID: g.ID(), Field: "Search", Err: ErrGameNameLength}
+	}
+	return nil
+}
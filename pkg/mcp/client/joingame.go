@@ -1,8 +1,31 @@
 package client
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
 // JoinGameRequest is structure of a MCP_JOINGAME request
 type JoinGame struct {
 	RequestID uint16
 	Name      string
 	Password  string
 }
+
+// ID returns the message ID of a MCP_JOINGAME request
+func (JoinGame) ID() mcp.MessageID {
+	return mcp.McpJoinGame
+}
+
+// Direction returns the direction of a MCP_JOINGAME request
+func (JoinGame) Direction() mcp.Direction {
+	return mcp.ClientToServer
+}
+
+// Validate returns an error if j violates the constraints of a MCP_JOINGAME request.
+func (j JoinGame) Validate() error {
+	if j.Name == "" || len(j.Name) > MaxGameNameLength {
+		return &mcp.ValidationError{MessageID: j.ID(), Field: "Name", Err: ErrGameNameLength}
+	}
+	if len(j.Password) > MaxGamePasswordLength {
+		return &mcp.ValidationError{MessageID: j.ID(), Field: "Password", Err: ErrGamePasswordLength}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJoinGameValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		msg   JoinGame
+		field string
+		err   error
+	}{
+		{"valid", JoinGame{Name: "baal-run-42", Password: "pw"}, "", nil},
+		{"no password", JoinGame{Name: "b"}, "", nil},
+		{"empty name", JoinGame{}, "Name", ErrGameNameLength},
+		{"long name", JoinGame{Name: strings.Repeat("n", MaxGameNameLength+1)}, "Name", ErrGameNameLength},
+		{"long password", JoinGame{Name: "b", Password: strings.Repeat("p", MaxGamePasswordLength+1)}, "Password", ErrGamePasswordLength},
+	} {
+		wantValidationError(t, tt.name, tt.msg.Validate(), tt.field, tt.err)
+	}
+}
+
+func TestGameInfoValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		field string
+	}{
+		{"baal-run-42", ""},
+		{"", "Name"},
+		{strings.Repeat("n", MaxGameNameLength+1), "Name"},
+	} {
+		wantValidationError(t, tt.name, GameInfo{Name: tt.name}.Validate(), tt.field, ErrGameNameLength)
+	}
+}
+
+func TestGameListValidate(t *testing.T) {
+	for _, tt := range []struct {
+		search string
+		field  string
+	}{
+		{"", ""},
+		{strings.Repeat("s", MaxGameNameLength), ""},
+		{strings.Repeat("s", MaxGameNameLength+1), "Search"},
+	} {
+		wantValidationError(t, tt.search, GameList{Search: tt.search}.Validate(), tt.field, ErrGameNameLength)
+	}
+}
@@ -0,0 +1,17 @@
+package client
+
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+// MOTDRequest is the structure of a MCP_MOTD request
+type MOTD struct {
+}
+
+// ID returns the message ID of a MCP_MOTD request
+func (MOTD) ID() mcp.MessageID {
+	return mcp.McpMOTD
+}
+
+// Direction returns the direction of a MCP_MOTD request
+func (MOTD) Direction() mcp.Direction {
+	return mcp.ClientToServer
+}
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+func TestMOTDRoundTrip(t *testing.T) {
+	want := []byte{0x03, 0x00, 0x12}
+
+	got, err := mcp.MarshalMessage(&MOTD{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got % x, want % x", got, want)
+	}
+
+	msg, err := mcp.Decode(mcp.ClientToServer, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(msg, &MOTD{}) {
+		t.Errorf("decoded %#v", msg)
+	}
+}
@@ -0,0 +1,22 @@
+//go:generate go run ../../../internal/cmd/binarygen -type=Startup,CharCreate,CreateGame,JoinGame,GameList,GameInfo,CharLogon,CharDelete,RequestLadderData,MOTD,CancelCreateGame,CharRank,CharList,CharUpgrade,CharList2
+package client
+
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+func init() {
+	mcp.Register(Startup{})
+	mcp.Register(CharCreate{})
+	mcp.Register(CreateGame{})
+	mcp.Register(JoinGame{})
+	mcp.Register(GameList{})
+	mcp.Register(GameInfo{})
+	mcp.Register(CharLogon{})
+	mcp.Register(CharDelete{})
+	mcp.Register(RequestLadderData{})
+	mcp.Register(MOTD{})
+	mcp.Register(CancelCreateGame{})
+	mcp.Register(CharRank{})
+	mcp.Register(CharList{})
+	mcp.Register(CharUpgrade{})
+	mcp.Register(CharList2{})
+}
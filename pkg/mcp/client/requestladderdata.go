@@ -0,0 +1,19 @@
+package client
+
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+// RequestLadderDataRequest is the structure of a MCP_REQUESTLADDERDATA request
+type RequestLadderData struct {
+	LadderType       uint8
+	StartingPosition uint16
+}
+
+// ID returns the message ID of a MCP_REQUESTLADDERDATA request
+func (RequestLadderData) ID() mcp.MessageID {
+	return mcp.McpRequestLadderData
+}
+
+// Direction returns the direction of a MCP_REQUESTLADDERDATA request
+func (RequestLadderData) Direction() mcp.Direction {
+	return mcp.ClientToServer
+}
@@ -1,5 +1,7 @@
 package client
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
 // StartupRequest is structure of a MCP_STARTUP request
 type Startup struct {
 	MCPCookie  uint32
@@ -8,3 +10,13 @@ type Startup struct {
 	Chunk2     [12]uint32
 	UniqueName string
 }
+
+// ID returns the message ID of a MCP_STARTUP request
+func (Startup) ID() mcp.MessageID {
+	return mcp.McpStartup
+}
+
+// Direction returns the direction of a MCP_STARTUP request
+func (Startup) Direction() mcp.Direction {
+	return mcp.ClientToServer
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+// wantValidationError checks that err is nil when field is empty and
+// otherwise a *mcp.ValidationError for field wrapping want.
+func wantValidationError(t *testing.T, name string, err error, field string, want error) {
+	t.Helper()
+	if field == "" {
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+		}
+		return
+	}
+	e, ok := err.(*mcp.ValidationError)
+	if !ok {
+		t.Errorf("%s: got %v, want *mcp.ValidationError", name, err)
+		return
+	}
+	if e.Field != field || e.Err != want {
+		t.Errorf("%s: got %s: %v, want %s: %v", name, e.Field, e.Err, field, want)
+	}
+}
@@ -0,0 +1,213 @@
+package mcp
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// DefaultHandshakeTimeout is the time a Listener returned by Listen waits
+// for a client to send the protocol selector
+const DefaultHandshakeTimeout = 10 * time.Second
+
+// ClientHandshake sends the protocol selector on c. A non-zero timeout
+// bounds the write.
+func ClientHandshake(c net.Conn, timeout time.Duration) error {
+	if timeout > 0 {
+		if err := c.SetWriteDeadline(time.Now().Add(timeout)); err != nil {
+			return err
+		}
+		defer c.SetWriteDeadline(time.Time{})
+	}
+
+	_, err := c.Write([]byte{ProtocolSelector})
+	return err
+}
+
+// ServerHandshake reads the protocol selector from c, returning an
+// *InvalidProtocolError if the client selected another protocol. A
+// non-zero timeout bounds the read.
+func ServerHandshake(c net.Conn, timeout time.Duration) error {
+	if timeout > 0 {
+		if err := c.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return err
+		}
+		defer c.SetReadDeadline(time.Time{})
+	}
+
+	var b [1]byte
+	if _, err := io.ReadFull(c, b[:]); err != nil {
+		return err
+	}
+	if b[0] != ProtocolSelector {
+		return &InvalidProtocolError{Protocol: b[0]}
+	}
+	return nil
+}
+
+// Dial connects to the realm server at address on the named network and
+// sends the protocol selector. See net.Dial for the form of address.
+func Dial(network, address string) (net.Conn, error) {
+	return DialTimeout(network, address, 0)
+}
+
+// DialTimeout acts like Dial but takes a timeout covering both the
+// connection and the protocol selector. Zero means no timeout.
+func DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	start := time.Now()
+	c, err := net.DialTimeout(network, address, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout > 0 {
+		// Whatever the connection left of the timeout, but never none
+		if timeout -= time.Since(start); timeout <= 0 {
+			timeout = time.Nanosecond
+		}
+	}
+	if err := ClientHandshake(c, timeout); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Listener accepts realm connections which have sent the protocol
+// selector. Each connection is handshaken in its own goroutine, so clients
+// slow to send the selector do not delay the others. A Listener must not
+// be copied after first use.
+type Listener struct {
+	net.Listener
+
+	// Timeout bounds the wait for the protocol selector of each accepted
+	// connection. Zero means no timeout.
+	Timeout time.Duration
+
+	once    sync.Once
+	accepts chan accepted
+	done    chan struct{} // closed by Close
+	stopped chan struct{} // closed when the accept loop exits
+	err     error         // why the accept loop exited
+
+	mu          sync.Mutex
+	closed      bool
+	handshaking map[net.Conn]struct{}
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// accepted is a connection which completed or failed the handshake, or a
+// temporary error of the underlying listener
+type accepted struct {
+	c   net.Conn
+	err error
+}
+
+// Listen announces on the local network address and returns a Listener
+// waiting DefaultHandshakeTimeout for protocol selectors. See net.Listen
+// for the form of address.
+func Listen(network, address string) (*Listener, error) {
+	l, err := net.Listen(network, address)
+	if err != nil {
+		return nil, err
+	}
+	return &Listener{Listener: l, Timeout: DefaultHandshakeTimeout}, nil
+}
+
+// Accept waits for the next connection to send its protocol selector.
+// Connections failing the handshake are closed and reported with a
+// *HandshakeError, which is temporary so accept loops may continue.
+func (l *Listener) Accept() (net.Conn, error) {
+	l.once.Do(l.start)
+	select {
+	case a := <-l.accepts:
+		return a.c, a.err
+	case <-l.stopped:
+		return nil, l.err
+	}
+}
+
+// Close stops accepting connections and closes the connections which
+// have not yet been returned by Accept.
+func (l *Listener) Close() error {
+	l.once.Do(l.start)
+	l.closeOnce.Do(func() {
+		close(l.done)
+		l.closeErr = l.Listener.Close()
+
+		l.mu.Lock()
+		l.closed = true
+		for c := range l.handshaking {
+			c.Close()
+		}
+		l.mu.Unlock()
+	})
+	return l.closeErr
+}
+
+func (l *Listener) start() {
+	l.accepts = make(chan accepted)
+	l.done = make(chan struct{})
+	l.stopped = make(chan struct{})
+	l.handshaking = make(map[net.Conn]struct{})
+	go l.acceptLoop()
+}
+
+// acceptLoop accepts connections until the underlying listener fails
+// permanently, handshaking each in a new goroutine.
+func (l *Listener) acceptLoop() {
+	defer close(l.stopped)
+	for {
+		c, err := l.Listener.Accept()
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() && l.deliver(accepted{err: err}) {
+				continue
+			}
+			l.err = err
+			return
+		}
+		go l.handshake(c)
+	}
+}
+
+// handshake reads the protocol selector of c, which Close closes if it
+// is called first.
+func (l *Listener) handshake(c net.Conn) {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		c.Close()
+		return
+	}
+	l.handshaking[c] = struct{}{}
+	l.mu.Unlock()
+
+	err := ServerHandshake(c, l.Timeout)
+
+	l.mu.Lock()
+	delete(l.handshaking, c)
+	l.mu.Unlock()
+
+	a := accepted{c: c}
+	if err != nil {
+		c.Close()
+		a = accepted{err: &HandshakeError{RemoteAddr: c.RemoteAddr(), Err: err}}
+	}
+	if !l.deliver(a) && a.c != nil {
+		a.c.Close()
+	}
+}
+
+// deliver hands a to Accept, reporting false if the listener was closed
+// first.
+func (l *Listener) deliver(a accepted) bool {
+	select {
+	case l.accepts <- a:
+		return true
+	case <-l.done:
+		return false
+	}
+}
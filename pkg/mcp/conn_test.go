@@ -0,0 +1,269 @@
+package mcp_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/client"
+)
+
+func listen(t *testing.T) *mcp.Listener {
+	t.Helper()
+	l, err := mcp.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on loopback: %s", err)
+	}
+	return l
+}
+
+func TestDialListen(t *testing.T) {
+	l := listen(t)
+	defer l.Close()
+
+	sent := &client.CharLogon{CharacterName: "Conan"}
+	errc := make(chan error, 1)
+	go func() {
+		c, err := mcp.DialTimeout("tcp", l.Addr().String(), time.Second)
+		if err != nil {
+			errc <- err
+			return
+		}
+		defer c.Close()
+		errc <- mcp.NewWriter(c).WriteMessage(sent)
+	}()
+
+	c, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// The selector has been consumed, so the first byte read starts a frame.
+	got, err := mcp.NewReader(c).ReadMessage(mcp.ClientToServer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cl, ok := got.(*client.CharLogon); !ok || *cl != *sent {
+		t.Errorf("got %#v, want %#v", got, sent)
+	}
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListenerInvalidProtocol(t *testing.T) {
+	l := listen(t)
+	defer l.Close()
+
+	go func() {
+		c, err := net.Dial("tcp", l.Addr().String())
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		// BNFTP
+		c.Write([]byte{0x02})
+		c.Read(make([]byte, 1))
+	}()
+
+	_, err := l.Accept()
+	he, ok := err.(*mcp.HandshakeError)
+	if !ok {
+		t.Fatalf("got %v, want *mcp.HandshakeError", err)
+	}
+	if !he.Temporary() || he.Timeout() {
+		t.Errorf("Temporary() = %t, Timeout() = %t", he.Temporary(), he.Timeout())
+	}
+	if pe, ok := he.Err.(*mcp.InvalidProtocolError); !ok || pe.Protocol != 0x02 {
+		t.Errorf("got %#v, want protocol 0x02", he.Err)
+	}
+}
+
+func TestListenerTimeout(t *testing.T) {
+	l := listen(t)
+	defer l.Close()
+	l.Timeout = 50 * time.Millisecond
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		c, err := net.Dial("tcp", l.Addr().String())
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		<-done
+	}()
+
+	_, err := l.Accept()
+	he, ok := err.(*mcp.HandshakeError)
+	if !ok || !he.Timeout() {
+		t.Errorf("got %v, want a timed out *mcp.HandshakeError", err)
+	}
+}
+
+func TestListenerSilentClient(t *testing.T) {
+	l := listen(t)
+	defer l.Close()
+
+	// A client which never sends the selector must not hold up the next.
+	silent, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer silent.Close()
+
+	errc := make(chan error, 1)
+	go func() {
+		c, err := mcp.DialTimeout("tcp", l.Addr().String(), time.Second)
+		if err == nil {
+			c.Close()
+		}
+		errc <- err
+	}()
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+
+	accepted := make(chan error, 1)
+	go func() {
+		c, err := l.Accept()
+		if err == nil {
+			c.Close()
+		}
+		accepted <- err
+	}()
+	select {
+	case err := <-accepted:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Accept blocked on a client which did not send the selector")
+	}
+}
+
+func TestListenerClose(t *testing.T) {
+	l := listen(t)
+
+	accepted := make(chan error, 1)
+	go func() {
+		_, err := l.Accept()
+		accepted <- err
+	}()
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-accepted:
+		if err == nil {
+			t.Fatal("Accept returned a connection after Close")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Accept blocked after Close")
+	}
+	if _, err := l.Accept(); err == nil {
+		t.Fatal("Accept returned a connection after Close")
+	}
+}
+
+func TestListenerCloseHandshaking(t *testing.T) {
+	l := listen(t)
+	l.Timeout = 0
+
+	go l.Accept()
+
+	silent, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer silent.Close()
+	// Give the listener time to start the handshake.
+	time.Sleep(100 * time.Millisecond)
+
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	silent.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err := silent.Read(make([]byte, 1)); err == nil {
+		t.Fatal("read data from a connection closed by Close")
+	} else if ne, ok := err.(net.Error); ok && ne.Timeout() {
+		t.Fatal("Close left a handshaking connection open")
+	}
+}
+
+// temporaryError is a net.Error which is always temporary
+type temporaryError struct{}
+
+func (temporaryError) Error() string   { return "temporary failure" }
+func (temporaryError) Timeout() bool   { return false }
+func (temporaryError) Temporary() bool { return true }
+
+// failingListener fails every Accept with a temporary error
+type failingListener struct{ net.Listener }
+
+func (failingListener) Accept() (net.Conn, error) { return nil, temporaryError{} }
+func (failingListener) Close() error              { return nil }
+
+func TestListenerCloseAfterTemporaryError(t *testing.T) {
+	l := &mcp.Listener{Listener: failingListener{}}
+	if _, err := l.Accept(); err != (temporaryError{}) {
+		t.Fatalf("got %v, want the temporary error", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	accepted := make(chan error, 1)
+	go func() {
+		for i := 0; i < 10; i++ {
+			if _, err := l.Accept(); err == nil {
+				accepted <- err
+				return
+			}
+		}
+		accepted <- temporaryError{}
+	}()
+	select {
+	case err := <-accepted:
+		if err == nil {
+			t.Fatal("Accept returned a connection after Close")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Accept blocked after Close")
+	}
+}
+
+func TestInvalidProtocolError(t *testing.T) {
+	err := &mcp.InvalidProtocolError{Protocol: 0x02}
+	if got, want := err.Error(), "mcp: invalid protocol selector 0x02"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestServerHandshake(t *testing.T) {
+	for _, tt := range []struct {
+		selector byte
+		ok       bool
+	}{
+		{mcp.ProtocolSelector, true},
+		{0x02, false},
+	} {
+		c, s := net.Pipe()
+		go func() {
+			c.Write([]byte{tt.selector})
+			c.Close()
+		}()
+
+		err := mcp.ServerHandshake(s, time.Second)
+		s.Close()
+		if tt.ok && err != nil {
+			t.Errorf("selector 0x%02x: %s", tt.selector, err)
+		}
+		if _, ok := err.(*mcp.InvalidProtocolError); !tt.ok && !ok {
+			t.Errorf("selector 0x%02x: got %v, want *mcp.InvalidProtocolError", tt.selector, err)
+		}
+	}
+}
@@ -1,32 +1,40 @@
 //go:generate $GOBIN/stringer -type=MessageID
 package mcp
 
+const (
+	HeaderLength = 3 // bytes
+
+	// ProtocolSelector is the byte a client sends ahead of its first
+	// frame to select the MCP protocol
+	ProtocolSelector = 0x01
+)
+
 type MessageID uint8
 
 const (
-	McpStartup MessageID = iota + 1
-	McpCharCreate
-	McpCreateGame
-	McpJoinGame
-	McpGameList
-	McpGameInfo
-	McpCharLogon
+	McpStartup    MessageID = 0x01
+	McpCharCreate MessageID = 0x02
+	McpCreateGame MessageID = 0x03
+	McpJoinGame   MessageID = 0x04
+	McpGameList   MessageID = 0x05
+	McpGameInfo   MessageID = 0x06
+	McpCharLogon  MessageID = 0x07
 	// 0x08
 	// 0x09
-	McpCharDelete
+	McpCharDelete MessageID = 0x0a
 	// 0x0b
 	// 0x0c
 	// 0x0d
 	// 0x0e
 	// 0x0f
 	// 0x10
-	McpRequestLadderData
-	McpMOTD
-	McpCancelGameCreate
-	McpCreateQueue
+	McpRequestLadderData MessageID = 0x11
+	McpMOTD              MessageID = 0x12
+	McpCancelGameCreate  MessageID = 0x13
+	McpCreateQueue       MessageID = 0x14
 	// 0x15
-	McpCharRank
-	McpCharList
-	McpCharUpgrade
-	McpCharList2
+	McpCharRank    MessageID = 0x16
+	McpCharList    MessageID = 0x17
+	McpCharUpgrade MessageID = 0x18
+	McpCharList2   MessageID = 0x19
 )
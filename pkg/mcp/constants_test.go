@@ -0,0 +1,41 @@
+package mcp_test
+
+import (
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+// TestMessageIDValues pins the wire value of every message ID. The gaps in
+// the numbering are deliberate.
+func TestMessageIDValues(t *testing.T) {
+	for _, tt := range []struct {
+		id   mcp.MessageID
+		name string
+		want uint8
+	}{
+		{mcp.McpStartup, "McpStartup", 0x01},
+		{mcp.McpCharCreate, "McpCharCreate", 0x02},
+		{mcp.McpCreateGame, "McpCreateGame", 0x03},
+		{mcp.McpJoinGame, "McpJoinGame", 0x04},
+		{mcp.McpGameList, "McpGameList", 0x05},
+		{mcp.McpGameInfo, "McpGameInfo", 0x06},
+		{mcp.McpCharLogon, "McpCharLogon", 0x07},
+		{mcp.McpCharDelete, "McpCharDelete", 0x0a},
+		{mcp.McpRequestLadderData, "McpRequestLadderData", 0x11},
+		{mcp.McpMOTD, "McpMOTD", 0x12},
+		{mcp.McpCancelGameCreate, "McpCancelGameCreate", 0x13},
+		{mcp.McpCreateQueue, "McpCreateQueue", 0x14},
+		{mcp.McpCharRank, "McpCharRank", 0x16},
+		{mcp.McpCharList, "McpCharList", 0x17},
+		{mcp.McpCharUpgrade, "McpCharUpgrade", 0x18},
+		{mcp.McpCharList2, "McpCharList2", 0x19},
+	} {
+		if uint8(tt.id) != tt.want {
+			t.Errorf("%s = %#02x, want %#02x", tt.name, uint8(tt.id), tt.want)
+		}
+		if got := tt.id.String(); got != tt.name {
+			t.Errorf("MessageID(%#02x).String() = %q, want %q", tt.want, got, tt.name)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package mcp_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/samlitowitz/bnet-encoding/pkg/encoding/bnet"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/client"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/server"
+)
+
+const (
+	statstringConan = "\x84\x80\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\x05\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\x2a\xa0\x8a\x80\x80\xff\x80\x80"
+	statstringXena  = "\x84\x80\x39\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01\x80\x80\x80\x80\xff\x80\x80"
+)
+
+// corpus pairs each testdata frame with the message it encodes. The frames
+// are synthetic, assembled by hand from the protocol documentation rather
+// than captured from a realm server; see testdata/README.md.
+var corpus = []struct {
+	dir  mcp.Direction
+	file string
+	want mcp.Message
+}{
+	{mcp.ClientToServer, "startup", &client.Startup{
+		MCPCookie: 0x8c2b0f73,
+		MCPStatus: 0x00000000,
+		Chunk1:    [2]uint32{0x1f7c26d5, 0x0ae1c8b4},
+		Chunk2: [12]uint32{
+			0x11111111, 0x22222222, 0x33333333, 0x44444444, 0x55555555, 0x66666666,
+			0x77777777, 0x88888888, 0x99999999, 0xaaaaaaaa, 0xbbbbbbbb, 0xcccccccc,
+		},
+		UniqueName: "Conan",
+	}},
+	{mcp.ClientToServer, "charcreate", &client.CharCreate{Class: mcp.ClassBarbarian, Flags: client.CharCreateHardcore | client.CharCreateExpansion, Name: "Conan"}},
+	{mcp.ClientToServer, "creategame", &client.CreateGame{
+		RequestID:        0x0002,
+		Difficulty:       mcp.DifficultyHell,
+		Unknown:          0x01,
+		LevelRestriction: 10,
+		MaxPlayers:       8,
+		Name:             "baal-run-42",
+		Password:         "pw",
+		Description:      "fast runs",
+	}},
+	{mcp.ClientToServer, "joingame", &client.JoinGame{RequestID: 0x0003, Name: "baal-run-42", Password: "pw"}},
+	{mcp.ClientToServer, "gamelist", &client.GameList{RequestID: 0x0004, Search: "baal"}},
+	{mcp.ClientToServer, "gameinfo", &client.GameInfo{RequestID: 0x0005, Name: "baal-run-42"}},
+	{mcp.ClientToServer, "charlogon", &client.CharLogon{CharacterName: "Conan"}},
+	{mcp.ClientToServer, "chardelete", &client.CharDelete{CharacterName: "Xena"}},
+	{mcp.ClientToServer, "requestladderdata", &client.RequestLadderData{LadderType: 0x13}},
+	{mcp.ClientToServer, "motd", &client.MOTD{}},
+	{mcp.ClientToServer, "cancelcreategame", &client.CancelCreateGame{}},
+	{mcp.ClientToServer, "charrank", &client.CharRank{Hardcore: true, Expansion: true, Class: mcp.ClassBarbarian, CharacterName: "Conan"}},
+	{mcp.ClientToServer, "charlist", &client.CharList{RequestCount: 8}},
+	{mcp.ClientToServer, "charupgrade", &client.CharUpgrade{CharacterName: "Xena"}},
+	{mcp.ClientToServer, "charlist2", &client.CharList2{RequestCount: 8}},
+
+	{mcp.ServerToClient, "startup", &server.Startup{Result: server.StartupSuccess}},
+	{mcp.ServerToClient, "charcreate", &server.CharCreate{Result: server.CharCreateAlreadyExists}},
+	{mcp.ServerToClient, "creategame", &server.CreateGame{RequestID: 0x0002, GameToken: 0x0031, Result: server.CreateGameSuccess}},
+	{mcp.ServerToClient, "joingame", &server.JoinGame{
+		RequestID:    0x0003,
+		GameToken:    0x0031,
+		GameServerIP: [4]uint8{192, 168, 1, 20},
+		GameHash:     0x6a8f5e21,
+		Result:       server.JoinGameSuccess,
+	}},
+	{mcp.ServerToClient, "gamelist", &server.GameList{
+		RequestID:   0x0004,
+		Index:       0x0000002a,
+		PlayerCount: 3,
+		Status:      0x00300004,
+		Name:        "baal-run-42",
+		Description: "fast runs",
+	}},
+	{mcp.ServerToClient, "gameinfo", &server.GameInfo{
+		RequestID:                  0x0005,
+		Status:                     0x00300004,
+		Uptime:                     3600,
+		LevelRestrictionLevel:      1,
+		LevelRestrictionDifference: 10,
+		MaxPlayers:                 8,
+		CharacterCount:             2,
+		CharacterClasses:           [16]uint8{uint8(mcp.ClassBarbarian), uint8(mcp.ClassAmazon)},
+		CharacterLevels:            [16]uint8{42, 1},
+		Description:                "fast runs",
+		CharacterNames:             "Conan\x00Xena",
+	}},
+	{mcp.ServerToClient, "charlogon", &server.CharLogon{Result: server.CharLogonSuccess}},
+	{mcp.ServerToClient, "chardelete", &server.CharDelete{Result: server.CharDeleteNotFound}},
+	{mcp.ServerToClient, "requestladderdata", &server.RequestLadderData{
+		LadderType: 0x13,
+		TotalSize:  64,
+		ChunkSize:  64,
+		Data: []uint8{
+			0x02, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x86, 0x57, 0xd6, 0xd1,
+			0x00, 0x00, 0x00, 0x00, 0x04, 0x00, 0x63, 0x00, 0x43, 0x6f, 0x6e, 0x61,
+			0x6e, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x60, 0xe3, 0x16, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x32, 0x00,
+			0x58, 0x65, 0x6e, 0x61, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00,
+		},
+	}},
+	{mcp.ServerToClient, "motd", &server.MOTD{Message: "Welcome to the realm\nLadder resets soon"}},
+	{mcp.ServerToClient, "createqueue", &server.CreateQueue{Position: 5}},
+	{mcp.ServerToClient, "charrank", &server.CharRank{Rank: 17}},
+	{mcp.ServerToClient, "charlist", &server.CharList{
+		RequestCount:  8,
+		ExistCount:    2,
+		ReturnedCount: 2,
+		Characters: []server.CharListCharacter{
+			{Name: "Conan", Statstring: statstringConan},
+			{Name: "Xena", Statstring: statstringXena},
+		},
+	}},
+	{mcp.ServerToClient, "charupgrade", &server.CharUpgrade{Result: server.CharUpgradeAlreadyExpansion}},
+	{mcp.ServerToClient, "charlist2", &server.CharList2{
+		RequestCount:  8,
+		ExistCount:    2,
+		ReturnedCount: 2,
+		Characters: []server.CharList2Character{
+			{ExpirationDate: 0x5cc2a7a0, Name: "Conan", Statstring: statstringConan},
+			{ExpirationDate: 0x5cc2a7a0, Name: "Xena", Statstring: statstringXena},
+		},
+	}},
+}
+
+// readVector returns the frame stored in a testdata hex file. Text
+// following a '#' on a line is a comment.
+func readVector(t testing.TB, dir mcp.Direction, name string) []byte {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", dir.String(), name+".hex"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var digits strings.Builder
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		digits.WriteString(strings.Join(strings.Fields(line), ""))
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	frame, err := hex.DecodeString(digits.String())
+	if err != nil {
+		t.Fatalf("%s/%s: %s", dir, name, err)
+	}
+	return frame
+}
+
+// TestCorpusSource checks that every vector records where its frame came
+// from on its second line.
+func TestCorpusSource(t *testing.T) {
+	for _, tc := range corpus {
+		b, err := ioutil.ReadFile(filepath.Join("testdata", tc.dir.String(), tc.file+".hex"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.SplitN(string(b), "\n", 3)
+		if len(lines) < 2 || !strings.HasPrefix(lines[1], "# Source: ") {
+			t.Errorf("%s/%s: second line does not record the source", tc.dir, tc.file)
+		}
+	}
+}
+
+func TestCorpusDecode(t *testing.T) {
+	for _, tc := range corpus {
+		frame := readVector(t, tc.dir, tc.file)
+
+		got, err := mcp.Decode(tc.dir, frame)
+		if err != nil {
+			t.Errorf("%s/%s: decode: %s", tc.dir, tc.file, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s/%s: decoded\n%#v\nwant\n%#v", tc.dir, tc.file, got, tc.want)
+		}
+	}
+}
+
+func TestCorpusRoundTrip(t *testing.T) {
+	for _, tc := range corpus {
+		frame := readVector(t, tc.dir, tc.file)
+
+		msg, err := mcp.Decode(tc.dir, frame)
+		if err != nil {
+			t.Errorf("%s/%s: decode: %s", tc.dir, tc.file, err)
+			continue
+		}
+		got, err := mcp.MarshalMessage(msg)
+		if err != nil {
+			t.Errorf("%s/%s: encode: %s", tc.dir, tc.file, err)
+			continue
+		}
+		if !bytes.Equal(got, frame) {
+			t.Errorf("%s/%s: encoded\n% x\nwant\n% x", tc.dir, tc.file, got, frame)
+		}
+	}
+}
+
+func TestCorpusReflectiveEncoding(t *testing.T) {
+	for _, tc := range corpus {
+		frame := readVector(t, tc.dir, tc.file)
+
+		got, err := bnet.Marshal(tc.want)
+		if err != nil {
+			t.Errorf("%s/%s: %s", tc.dir, tc.file, err)
+			continue
+		}
+		if !bytes.Equal(got, frame[mcp.HeaderLength:]) {
+			t.Errorf("%s/%s: bnet.Marshal\n% x\nwant\n% x", tc.dir, tc.file, got, frame[mcp.HeaderLength:])
+		}
+	}
+}
+
+func TestCorpusStream(t *testing.T) {
+	for _, dir := range []mcp.Direction{mcp.ClientToServer, mcp.ServerToClient} {
+		var stream bytes.Buffer
+		var want []mcp.Message
+		for _, tc := range corpus {
+			if tc.dir == dir {
+				stream.Write(readVector(t, tc.dir, tc.file))
+				want = append(want, tc.want)
+			}
+		}
+
+		r := mcp.NewReader(iotest.OneByteReader(&stream))
+		r.Validate = true
+		for _, w := range want {
+			got, err := r.ReadMessage(dir)
+			if err != nil {
+				t.Fatalf("%s: reading %T: %s", dir, w, err)
+			}
+			if !reflect.DeepEqual(got, w) {
+				t.Errorf("%s: read\n%#v\nwant\n%#v", dir, got, w)
+			}
+		}
+		if _, err := r.ReadFrame(); err != io.EOF {
+			t.Errorf("%s: got %v at end of stream, want io.EOF", dir, err)
+		}
+	}
+}
+
+func TestCorpusFiles(t *testing.T) {
+	for _, dir := range []mcp.Direction{mcp.ClientToServer, mcp.ServerToClient} {
+		files, err := ioutil.ReadDir(filepath.Join("testdata", dir.String()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, fi := range files {
+			name := strings.TrimSuffix(fi.Name(), ".hex")
+			found := false
+			for _, tc := range corpus {
+				found = found || (tc.dir == dir && tc.file == name)
+			}
+			if !found {
+				t.Errorf("%s/%s has no expected message", dir, fi.Name())
+			}
+		}
+	}
+}
@@ -0,0 +1,16 @@
+//go:generate $GOBIN/stringer -type=Difficulty -trimprefix=Difficulty
+package mcp
+
+// Difficulty is the difficulty of a game
+type Difficulty uint32
+
+const (
+	DifficultyNormal    Difficulty = 0x0000
+	DifficultyNightmare Difficulty = 0x1000
+	DifficultyHell      Difficulty = 0x2000
+)
+
+// Valid reports whether d is a known difficulty.
+func (d Difficulty) Valid() bool {
+	return d == DifficultyNormal || d == DifficultyNightmare || d == DifficultyHell
+}
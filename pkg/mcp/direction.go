@@ -0,0 +1,21 @@
+package mcp
+
+import "strconv"
+
+// Direction is the direction in which a message travels
+type Direction uint8
+
+const (
+	ClientToServer Direction = iota
+	ServerToClient
+)
+
+func (d Direction) String() string {
+	switch d {
+	case ClientToServer:
+		return "c2s"
+	case ServerToClient:
+		return "s2c"
+	}
+	return "Direction(" + strconv.Itoa(int(d)) + ")"
+}
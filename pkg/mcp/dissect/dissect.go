@@ -0,0 +1,240 @@
+// Package dissect splits MCP frames into the bytes of their individual
+// fields for debugging.
+package dissect
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/samlitowitz/bnet-mcp/internal/bnettag"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+	_ "github.com/samlitowitz/bnet-mcp/pkg/mcp/client"
+	_ "github.com/samlitowitz/bnet-mcp/pkg/mcp/server"
+)
+
+// bytesPerLine is the number of raw bytes shown on each line of a dump
+const bytesPerLine = 8
+
+// Trailing is the name of the field holding payload bytes not consumed by
+// the message
+const Trailing = "(trailing)"
+
+// Field is a single field of a dissected frame
+type Field struct {
+	// Name is the path of the field within the message, such as
+	// "Characters[1].Statstring". Header fields are prefixed "Header.".
+	Name string
+	// Offset is the position of the field from the start of the frame
+	Offset int
+	Raw    []byte
+	// Value is the decoded value of the field
+	Value interface{}
+}
+
+// String returns the decoded value of f formatted for display. Enums are
+// shown by name and IP addresses as dotted quads.
+func (f Field) String() string {
+	return formatValue(f.Name, reflect.ValueOf(f.Value))
+}
+
+// Frame is a dissected MCP frame
+type Frame struct {
+	Direction mcp.Direction
+	Header    mcp.Header
+	Message   mcp.Message
+	Fields    []Field
+}
+
+// Dissect decodes the frame at the start of frame sent in dir and splits
+// it into fields. Unregistered message IDs are dissected as a
+// *mcp.RawMessage.
+func Dissect(dir mcp.Direction, frame []byte) (*Frame, error) {
+	msg, _, err := mcp.DecodeLenient(dir, frame)
+	if _, ok := err.(*mcp.UnknownMessageError); ok {
+		msg, err = &mcp.RawMessage{Dir: dir}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Frame{Direction: dir, Message: msg}
+	if err := d.Header.UnmarshalBinary(frame); err != nil {
+		return nil, err
+	}
+	if raw, ok := msg.(*mcp.RawMessage); ok {
+		raw.MessageID = d.Header.MessageID
+		raw.Payload = frame[mcp.HeaderLength:d.Header.Length]
+	}
+
+	w := &walker{frame: frame[:d.Header.Length]}
+	w.add("Header.Length", 2, reflect.ValueOf(d.Header.Length))
+	w.add("Header.MessageID", 1, reflect.ValueOf(d.Header.MessageID))
+	if raw, ok := msg.(*mcp.RawMessage); ok {
+		w.add("Payload", len(raw.Payload), reflect.ValueOf(raw.Payload))
+	} else if err := w.walkStruct("", reflect.ValueOf(msg).Elem()); err != nil {
+		return nil, err
+	}
+	if w.off < len(w.frame) {
+		w.add(Trailing, len(w.frame)-w.off, reflect.ValueOf(w.frame[w.off:]))
+	}
+
+	d.Fields = w.fields
+	return d, nil
+}
+
+// WriteTo writes an annotated hexdump of f to w.
+func (f *Frame) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s, %d bytes\n", f.Direction, f.Header.MessageID, f.Header.Length)
+
+	width := 0
+	for _, field := range f.Fields {
+		if len(field.Name) > width {
+			width = len(field.Name)
+		}
+	}
+
+	for _, field := range f.Fields {
+		raw := field.Raw
+		off := field.Offset
+		name, value := field.Name, field.String()
+		for first := true; first || len(raw) > 0; first = false {
+			n := len(raw)
+			if n > bytesPerLine {
+				n = bytesPerLine
+			}
+			line := fmt.Sprintf("%04x  %-*s  %-*s  %s", off, bytesPerLine*3-1, fmt.Sprintf("% x", raw[:n]), width, name, value)
+			buf.WriteString(strings.TrimRight(line, " "))
+			buf.WriteByte('\n')
+			raw, off = raw[n:], off+n
+			name, value = "", ""
+		}
+	}
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+// String returns the annotated hexdump of f.
+func (f *Frame) String() string {
+	var b strings.Builder
+	f.WriteTo(&b)
+	return b.String()
+}
+
+// walker assigns consecutive frame bytes to the fields of a message
+type walker struct {
+	frame  []byte
+	off    int
+	fields []Field
+}
+
+func (w *walker) add(name string, n int, v reflect.Value) {
+	if rest := len(w.frame) - w.off; n > rest {
+		n = rest
+	}
+	w.fields = append(w.fields, Field{
+		Name:   name,
+		Offset: w.off,
+		Raw:    w.frame[w.off : w.off+n],
+		Value:  v.Interface(),
+	})
+	w.off += n
+}
+
+func (w *walker) walkStruct(prefix string, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := v.Field(i)
+		name := prefix + sf.Name
+
+		switch {
+		case fv.Kind() == reflect.Struct:
+			if err := w.walkStruct(name+".", fv); err != nil {
+				return err
+			}
+			continue
+		case (fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array) && fv.Type().Elem().Kind() == reflect.Struct:
+			for j := 0; j < fv.Len(); j++ {
+				if err := w.walkStruct(fmt.Sprintf("%s[%d].", name, j), fv.Index(j)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		n, err := size(fv, bnettag.Parse(sf.Tag))
+		if err != nil {
+			return fmt.Errorf("dissect: %s.%s: %s", t.Name(), sf.Name, err)
+		}
+		w.add(name, n, fv)
+	}
+	return nil
+}
+
+// size returns the number of bytes v occupies on the wire.
+func size(v reflect.Value, tags map[string]string) (int, error) {
+	switch v.Kind() {
+	case reflect.Uint8, reflect.Int8:
+		return 1, nil
+	case reflect.Uint16, reflect.Int16:
+		return 2, nil
+	case reflect.Uint32, reflect.Int32:
+		return 4, nil
+	case reflect.Uint64, reflect.Int64:
+		return 8, nil
+	case reflect.Bool:
+		switch tags["size"] {
+		case "uint8":
+			return 1, nil
+		case "uint32":
+			return 4, nil
+		}
+		return 0, fmt.Errorf("bool without size tag")
+	case reflect.String:
+		return v.Len() + 1, nil
+	case reflect.Array, reflect.Slice:
+		n := 0
+		for i := 0; i < v.Len(); i++ {
+			m, err := size(v.Index(i), tags)
+			if err != nil {
+				return 0, err
+			}
+			n += m
+		}
+		return n, nil
+	}
+	return 0, fmt.Errorf("unsupported kind %s", v.Kind())
+}
+
+func formatValue(name string, v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		switch v.Kind() {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return fmt.Sprintf("%s (%#x)", s, v.Uint())
+		}
+		return s.String()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("%q", v.String())
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("%d bytes", v.Len())
+		}
+	case reflect.Array:
+		if strings.HasSuffix(name, "IP") && v.Len() == 4 && v.Type().Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("%d.%d.%d.%d", v.Index(0).Uint(), v.Index(1).Uint(), v.Index(2).Uint(), v.Index(3).Uint())
+		}
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
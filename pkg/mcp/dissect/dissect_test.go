@@ -0,0 +1,106 @@
+package dissect
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+func TestDissectJoinGame(t *testing.T) {
+	frame := []byte{
+		0x15, 0x00, 0x04,
+		0x03, 0x00,
+		0x31, 0x00,
+		0x00, 0x00,
+		0xc0, 0xa8, 0x01, 0x14,
+		0x21, 0x5e, 0x8f, 0x6a,
+		0x2a, 0x00, 0x00, 0x00,
+	}
+
+	d, err := Dissect(mcp.ServerToClient, frame)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		name   string
+		offset int
+		size   int
+		value  string
+	}{
+		{"Header.Length", 0, 2, "21"},
+		{"Header.MessageID", 2, 1, "McpJoinGame (0x4)"},
+		{"RequestID", 3, 2, "3"},
+		{"GameToken", 5, 2, "49"},
+		{"Unknown", 7, 2, "0"},
+		{"GameServerIP", 9, 4, "192.168.1.20"},
+		{"GameHash", 13, 4, "1787780641"},
+		{"Result", 17, 4, "JoinGameNotFound (0x2a)"},
+	}
+	if len(d.Fields) != len(want) {
+		t.Fatalf("got %d fields, want %d", len(d.Fields), len(want))
+	}
+	for i, w := range want {
+		f := d.Fields[i]
+		if f.Name != w.name || f.Offset != w.offset || f.String() != w.value {
+			t.Errorf("field %d: got %s at %d = %s, want %s at %d = %s", i, f.Name, f.Offset, f, w.name, w.offset, w.value)
+		}
+		if !bytes.Equal(f.Raw, frame[w.offset:w.offset+w.size]) {
+			t.Errorf("%s: raw % x", f.Name, f.Raw)
+		}
+	}
+}
+
+func TestDissectNested(t *testing.T) {
+	frame := []byte{
+		0x18, 0x00, 0x19,
+		0x08, 0x00,
+		0x01, 0x00, 0x00, 0x00,
+		0x01, 0x00,
+		0xa0, 0xa7, 0xc2, 0x5c,
+		'X', 'e', 'n', 'a', 0x00,
+		'S', 'T', 0x00,
+		0xee,
+	}
+
+	d, err := Dissect(mcp.ServerToClient, frame)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, f := range d.Fields {
+		names = append(names, f.Name)
+	}
+	want := []string{
+		"Header.Length", "Header.MessageID", "RequestCount", "ExistCount", "ReturnedCount",
+		"Characters[0].ExpirationDate", "Characters[0].Name", "Characters[0].Statstring", Trailing,
+	}
+	if len(names) != len(want) {
+		t.Fatalf("got fields %q, want %q", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("got fields %q, want %q", names, want)
+		}
+	}
+	if last := d.Fields[len(d.Fields)-1]; last.Offset != 23 || !bytes.Equal(last.Raw, []byte{0xee}) {
+		t.Errorf("trailing field at %d: % x", last.Offset, last.Raw)
+	}
+}
+
+func TestDissectRaw(t *testing.T) {
+	d, err := Dissect(mcp.ClientToServer, []byte{0x05, 0x00, 0x0b, 0x01, 0x02})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "c2s MessageID(11), 5 bytes\n" +
+		"0000  05 00                    Header.Length     5\n" +
+		"0002  0b                       Header.MessageID  MessageID(11) (0xb)\n" +
+		"0003  01 02                    Payload           2 bytes\n"
+	if got := d.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+//go:generate go run ../../internal/cmd/enumgen -type=MessageID,CharacterClass,Difficulty
+package mcp
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var enums struct {
+	mu     sync.RWMutex
+	values map[reflect.Type]map[string]uint64
+}
+
+// RegisterEnum records values as the named values of their type, which
+// lets the JSON form of messages refer to them by name. It is intended to
+// be called from the init function of the packages defining enumerated
+// types, which internal/cmd/enumgen generates from their constants.
+// RegisterEnum panics if a value is not an unsigned integer.
+func RegisterEnum(values ...fmt.Stringer) {
+	enums.mu.Lock()
+	defer enums.mu.Unlock()
+
+	if enums.values == nil {
+		enums.values = make(map[reflect.Type]map[string]uint64)
+	}
+	for _, v := range values {
+		rv := reflect.ValueOf(v)
+		if !isUnsigned(rv.Kind()) {
+			panic("mcp: RegisterEnum called with " + rv.Type().String())
+		}
+		names := enums.values[rv.Type()]
+		if names == nil {
+			names = make(map[string]uint64)
+			enums.values[rv.Type()] = names
+		}
+		names[v.String()] = rv.Uint()
+	}
+}
+
+// isEnum reports whether t has registered values.
+func isEnum(t reflect.Type) bool {
+	enums.mu.RLock()
+	defer enums.mu.RUnlock()
+	_, ok := enums.values[t]
+	return ok
+}
+
+// enumValue returns the registered value of type t called name.
+func enumValue(t reflect.Type, name string) (uint64, bool) {
+	enums.mu.RLock()
+	defer enums.mu.RUnlock()
+	v, ok := enums.values[t][name]
+	return v, ok
+}
+
+// enumName returns the registered name of v.
+func enumName(v reflect.Value) (string, bool) {
+	s, ok := v.Interface().(fmt.Stringer)
+	if !ok {
+		return "", false
+	}
+	name := s.String()
+	if u, ok := enumValue(v.Type(), name); !ok || u != v.Uint() {
+		return "", false
+	}
+	return name, true
+}
+
+func isUnsigned(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// EnumValues returns the registered names of the values of the enumerated
+// type t, keyed by value. It returns nil if t has no registered values.
+func EnumValues(t reflect.Type) map[uint64]string {
+	enums.mu.RLock()
+	defer enums.mu.RUnlock()
+
+	names := enums.values[t]
+	if names == nil {
+		return nil
+	}
+	values := make(map[uint64]string, len(names))
+	for name, v := range names {
+		values[v] = name
+	}
+	return values
+}
@@ -0,0 +1,147 @@
+package mcp
+
+import (
+	"fmt"
+	"net"
+)
+
+// An InvalidLengthError occurs when a header declares a length shorter
+// than the header itself or longer than a frame can hold.
+type InvalidLengthError struct {
+	Length int
+}
+
+func (e *InvalidLengthError) Error() string {
+	return fmt.Sprintf("mcp: invalid frame length %d", e.Length)
+}
+
+// An UnknownMessageError occurs when no message is registered for an ID
+// in a given direction.
+type UnknownMessageError struct {
+	Direction Direction
+	MessageID MessageID
+}
+
+func (e *UnknownMessageError) Error() string {
+	return fmt.Sprintf("mcp: unknown %s message %s", e.Direction, e.MessageID)
+}
+
+// A ValidationError occurs when a message field violates the protocol.
+type ValidationError struct {
+	MessageID MessageID
+	Field     string
+	Err       error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s (%s.%s)", e.Err, e.MessageID, e.Field)
+}
+
+// Unwrap returns the underlying error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// A MalformedPayloadError occurs when the reflective decoder fails on a
+// payload it cannot index safely, such as a string missing its terminator.
+type MalformedPayloadError struct {
+	Type   string
+	Reason string
+}
+
+func (e *MalformedPayloadError) Error() string {
+	return fmt.Sprintf("mcp: malformed %s payload: %s", e.Type, e.Reason)
+}
+
+// A LengthMismatchError occurs in strict decoding when the length declared
+// by a header differs from the number of bytes in the frame.
+type LengthMismatchError struct {
+	MessageID MessageID
+	Length    int
+	Actual    int
+}
+
+func (e *LengthMismatchError) Error() string {
+	return fmt.Sprintf("mcp: %s header length %d does not match frame length %d", e.MessageID, e.Length, e.Actual)
+}
+
+// A ShortFrameError occurs in strict decoding when a frame ends before the
+// message it carries. Offset is relative to the start of the frame.
+type ShortFrameError struct {
+	MessageID MessageID
+	Offset    int
+	Err       error
+}
+
+func (e *ShortFrameError) Error() string {
+	return fmt.Sprintf("mcp: short %s frame at offset %d: %s", e.MessageID, e.Offset, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ShortFrameError) Unwrap() error {
+	return e.Err
+}
+
+// A TrailingDataError occurs in strict decoding when a message does not
+// consume its whole payload. Offset is relative to the start of the frame.
+type TrailingDataError struct {
+	MessageID MessageID
+	Offset    int
+	Length    int
+}
+
+func (e *TrailingDataError) Error() string {
+	return fmt.Sprintf("mcp: %d trailing bytes at offset %d of %s frame", e.Length, e.Offset, e.MessageID)
+}
+
+// A JSONError occurs when the JSON form of a message cannot be converted.
+// Field is the path of the offending field, if any.
+type JSONError struct {
+	Field  string
+	Reason string
+}
+
+func (e *JSONError) Error() string {
+	if e.Field == "" {
+		return "mcp: json: " + e.Reason
+	}
+	return fmt.Sprintf("mcp: json: %s: %s", e.Field, e.Reason)
+}
+
+// An InvalidProtocolError occurs when a connection opens with a protocol
+// selector other than ProtocolSelector.
+type InvalidProtocolError struct {
+	Protocol byte
+}
+
+func (e *InvalidProtocolError) Error() string {
+	return fmt.Sprintf("mcp: invalid protocol selector 0x%02x", e.Protocol)
+}
+
+// A HandshakeError occurs when an accepted connection fails to send the
+// protocol selector. It implements net.Error and is always temporary.
+type HandshakeError struct {
+	RemoteAddr net.Addr
+	Err        error
+}
+
+func (e *HandshakeError) Error() string {
+	return fmt.Sprintf("mcp: handshake with %s: %s", e.RemoteAddr, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *HandshakeError) Unwrap() error {
+	return e.Err
+}
+
+// Timeout reports whether the client did not send the protocol selector
+// in time.
+func (e *HandshakeError) Timeout() bool {
+	t, ok := e.Err.(interface{ Timeout() bool })
+	return ok && t.Timeout()
+}
+
+// Temporary returns true since the failure concerns a single connection.
+func (e *HandshakeError) Temporary() bool {
+	return true
+}
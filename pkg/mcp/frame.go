@@ -0,0 +1,150 @@
+package mcp
+
+import (
+	"io"
+	"math"
+)
+
+// Frame is a single MCP message as sent on the wire
+type Frame struct {
+	Header  Header
+	Payload []byte
+}
+
+// Reader reads MCP frames from an underlying io.Reader
+type Reader struct {
+	// Validate causes ReadMessage to return an error for messages
+	// violating the constraints of the protocol.
+	Validate bool
+
+	// Strict causes ReadMessage to return an error for messages which do
+	// not consume their whole payload. See DecodeStrict.
+	Strict bool
+
+	r   io.Reader
+	hdr [HeaderLength]byte
+}
+
+// NewReader returns a Reader reading frames from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: r}
+}
+
+// ReadFrame reads exactly one frame. Partial reads are retried until the
+// frame is complete and bytes belonging to following frames are left
+// unread. ReadFrame returns io.EOF only if no bytes were read, and
+// io.ErrUnexpectedEOF if the stream ends mid-frame.
+func (r *Reader) ReadFrame() (*Frame, error) {
+	if _, err := io.ReadFull(r.r, r.hdr[:]); err != nil {
+		return nil, err
+	}
+
+	f := &Frame{}
+	if err := f.Header.UnmarshalBinary(r.hdr[:]); err != nil {
+		return nil, err
+	}
+	if f.Header.Length < HeaderLength {
+		return nil, &InvalidLengthError{Length: int(f.Header.Length)}
+	}
+
+	f.Payload = make([]byte, int(f.Header.Length)-HeaderLength)
+	if _, err := io.ReadFull(r.r, f.Payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// ReadMessage reads one frame sent in dir and decodes the message it
+// contains. Frames with an unregistered message ID are returned as a
+// *RawMessage.
+func (r *Reader) ReadMessage(dir Direction) (Message, error) {
+	f, err := r.ReadFrame()
+	if err != nil {
+		return nil, err
+	}
+
+	var msg Message
+	if r.Strict {
+		msg, err = DecodeFrameStrict(dir, f)
+	} else {
+		msg, err = DecodeFrame(dir, f)
+	}
+	if _, ok := err.(*UnknownMessageError); ok {
+		return &RawMessage{MessageID: f.Header.MessageID, Dir: dir, Payload: f.Payload}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if r.Validate {
+		if err := Validate(msg); err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
+
+// Writer writes MCP frames to an underlying io.Writer
+type Writer struct {
+	// Validate causes WriteMessage to refuse messages violating the
+	// constraints of the protocol.
+	Validate bool
+
+	w   io.Writer
+	buf []byte
+}
+
+// NewWriter returns a Writer writing frames to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// WriteFrame writes f to the underlying writer in a single call. The
+// header length is computed from the payload and stored in f.
+func (w *Writer) WriteFrame(f *Frame) error {
+	buf, err := marshalFrame(&f.Header, f.Payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.w.Write(buf)
+	return err
+}
+
+// WriteMessage writes the frame for msg to the underlying writer in a
+// single call.
+func (w *Writer) WriteMessage(msg Message) error {
+	if w.Validate {
+		if err := Validate(msg); err != nil {
+			return err
+		}
+	}
+
+	buf, err := Append(w.buf[:0], msg.ID(), msg)
+	if err != nil {
+		return err
+	}
+	w.buf = buf
+
+	_, err = w.w.Write(buf)
+	return err
+}
+
+// marshalFrame sets h.Length from payload and returns the encoded header
+// followed by payload.
+func marshalFrame(h *Header, payload []byte) ([]byte, error) {
+	length := HeaderLength + len(payload)
+	if length > math.MaxUint16 {
+		return nil, &InvalidLengthError{Length: length}
+	}
+	h.Length = uint16(length)
+
+	buf, err := h.AppendBinary(make([]byte, 0, length))
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, payload...), nil
+}
@@ -0,0 +1,107 @@
+package mcp_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+func TestReadFrameCoalesced(t *testing.T) {
+	want := []*mcp.Frame{
+		{Header: mcp.Header{Length: 5, MessageID: mcp.McpStartup}, Payload: []byte{0xaa, 0xbb}},
+		{Header: mcp.Header{Length: 3, MessageID: mcp.McpMOTD}, Payload: []byte{}},
+		{Header: mcp.Header{Length: 4, MessageID: mcp.McpCharList}, Payload: []byte{0xcc}},
+	}
+
+	// bytes.Reader returns every frame from a single Read.
+	data := []byte{
+		0x05, 0x00, 0x01, 0xaa, 0xbb,
+		0x03, 0x00, 0x12,
+		0x04, 0x00, 0x17, 0xcc,
+	}
+	r := mcp.NewReader(bytes.NewReader(data))
+	for i, w := range want {
+		got, err := r.ReadFrame()
+		if err != nil {
+			t.Fatalf("frame %d: %s", i, err)
+		}
+		if !reflect.DeepEqual(got, w) {
+			t.Errorf("frame %d: got %+v, want %+v", i, got, w)
+		}
+	}
+	if _, err := r.ReadFrame(); err != io.EOF {
+		t.Errorf("got %v at end of stream, want io.EOF", err)
+	}
+}
+
+func TestReadFrameShort(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"empty", nil, io.EOF},
+		{"one header byte", []byte{0x05}, io.ErrUnexpectedEOF},
+		{"two header bytes", []byte{0x05, 0x00}, io.ErrUnexpectedEOF},
+		{"no payload", []byte{0x05, 0x00, 0x01}, io.ErrUnexpectedEOF},
+		{"truncated payload", []byte{0x05, 0x00, 0x01, 0xaa}, io.ErrUnexpectedEOF},
+	} {
+		f, err := mcp.NewReader(bytes.NewReader(tt.data)).ReadFrame()
+		if err != tt.err {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+		if f != nil {
+			t.Errorf("%s: returned %+v", tt.name, f)
+		}
+	}
+}
+
+func TestReadFrameInvalidLength(t *testing.T) {
+	for length := 0; length < mcp.HeaderLength; length++ {
+		data := []byte{byte(length), 0x00, 0x01, 0xaa, 0xbb}
+		_, err := mcp.NewReader(bytes.NewReader(data)).ReadFrame()
+		e, ok := err.(*mcp.InvalidLengthError)
+		if !ok {
+			t.Errorf("length %d: got %v, want *InvalidLengthError", length, err)
+			continue
+		}
+		if e.Length != length {
+			t.Errorf("length %d: got Length %d", length, e.Length)
+		}
+	}
+}
+
+func TestWriteFrameLength(t *testing.T) {
+	for _, stale := range []uint16{0, 2, 0xffff} {
+		var buf bytes.Buffer
+		f := &mcp.Frame{
+			Header:  mcp.Header{Length: stale, MessageID: mcp.McpStartup},
+			Payload: []byte{0xaa, 0xbb},
+		}
+		if err := mcp.NewWriter(&buf).WriteFrame(f); err != nil {
+			t.Fatal(err)
+		}
+		if f.Header.Length != 5 {
+			t.Errorf("stale length %d: Header.Length %d, want 5", stale, f.Header.Length)
+		}
+		want := []byte{0x05, 0x00, 0x01, 0xaa, 0xbb}
+		if !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("stale length %d: wrote % x, want % x", stale, buf.Bytes(), want)
+		}
+	}
+}
+
+func TestWriteFrameTooLong(t *testing.T) {
+	var buf bytes.Buffer
+	f := &mcp.Frame{Header: mcp.Header{MessageID: mcp.McpStartup}, Payload: make([]byte, 0xffff)}
+	err := mcp.NewWriter(&buf).WriteFrame(f)
+	if e, ok := err.(*mcp.InvalidLengthError); !ok || e.Length != 0xffff+mcp.HeaderLength {
+		t.Errorf("got %v, want *InvalidLengthError", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("wrote %d bytes", buf.Len())
+	}
+}
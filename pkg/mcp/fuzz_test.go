@@ -0,0 +1,149 @@
+//go:build go1.18
+// +build go1.18
+
+package mcp_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/server"
+)
+
+// addCorpus seeds f with the golden frames sent in direction dir.
+func addCorpus(f *testing.F, dir mcp.Direction) {
+	for _, tc := range corpus {
+		if tc.dir == dir {
+			f.Add(readVector(f, tc.dir, tc.file))
+		}
+	}
+}
+
+func FuzzHeader(f *testing.F) {
+	addCorpus(f, mcp.ClientToServer)
+	addCorpus(f, mcp.ServerToClient)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var h mcp.Header
+		if err := h.UnmarshalBinary(data); err != nil {
+			if len(data) >= mcp.HeaderLength {
+				t.Fatalf("header rejected: %s", err)
+			}
+			return
+		}
+		b, err := h.AppendBinary(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, data[:mcp.HeaderLength]) {
+			t.Fatalf("header re-encoded as % x, want % x", b, data[:mcp.HeaderLength])
+		}
+
+		r := mcp.NewReader(bytes.NewReader(data))
+		n := 0
+		for {
+			frame, err := r.ReadFrame()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return
+			}
+			if int(frame.Header.Length) != mcp.HeaderLength+len(frame.Payload) {
+				t.Fatalf("frame length %d with %d byte payload", frame.Header.Length, len(frame.Payload))
+			}
+			n += int(frame.Header.Length)
+		}
+		if n != len(data) {
+			t.Fatalf("read %d of %d bytes", n, len(data))
+		}
+	})
+}
+
+func FuzzDecodeClient(f *testing.F) {
+	addCorpus(f, mcp.ClientToServer)
+	f.Fuzz(func(t *testing.T, frame []byte) {
+		fuzzDecode(t, mcp.ClientToServer, frame)
+	})
+}
+
+func FuzzDecodeServer(f *testing.F) {
+	addCorpus(f, mcp.ServerToClient)
+	f.Fuzz(func(t *testing.T, frame []byte) {
+		fuzzDecode(t, mcp.ServerToClient, frame)
+	})
+}
+
+func FuzzUnmarshalReflective(f *testing.F) {
+	for _, tc := range corpus {
+		f.Add(readVector(f, tc.dir, tc.file)[mcp.HeaderLength:])
+	}
+	f.Add([]byte("\x00Welcome"))
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		for _, tc := range corpus {
+			_ = mcp.Unmarshal(payload, reflective(tc.want))
+		}
+	})
+}
+
+// reflective returns a pointer to a method-less copy of the struct msg
+// points to, so that mcp.Unmarshal decodes it with the reflective path.
+func reflective(msg mcp.Message) interface{} {
+	t := reflect.TypeOf(msg).Elem()
+	fields := make([]reflect.StructField, t.NumField())
+	for i := range fields {
+		fields[i] = t.Field(i)
+	}
+	return reflect.New(reflect.StructOf(fields)).Interface()
+}
+
+// fuzzDecode decodes frame and checks that any message it yields encodes
+// to a frame which decodes back to the same message.
+func fuzzDecode(t *testing.T, dir mcp.Direction, frame []byte) {
+	msg, err := mcp.Decode(dir, frame)
+	if err != nil {
+		return
+	}
+	_ = mcp.Validate(msg)
+	inspect(msg)
+
+	b, err := mcp.MarshalMessage(msg)
+	if err != nil {
+		t.Fatalf("%T: encode: %s", msg, err)
+	}
+	again, err := mcp.Decode(dir, b)
+	if err != nil {
+		t.Fatalf("%T: decode of re-encoded frame: %s", msg, err)
+	}
+	if !reflect.DeepEqual(again, msg) {
+		t.Fatalf("%T: round trip\n%#v\nwant\n%#v", msg, again, msg)
+	}
+}
+
+// inspect calls the accessors which interpret message contents beyond the
+// wire layout.
+func inspect(msg mcp.Message) {
+	switch m := msg.(type) {
+	case *server.GameInfo:
+		_, _ = m.Players()
+	case *server.MOTD:
+		_ = m.Lines()
+	case *server.CharList:
+		for _, c := range m.Characters {
+			_, _ = c.ParseStatstring()
+		}
+	case *server.CharList2:
+		for _, c := range m.Characters {
+			_, _ = c.ParseStatstring()
+		}
+	case *server.RequestLadderData:
+		var r server.LadderReassembler
+		if done, err := r.Add(m); done && err == nil {
+			_, _ = r.Entries()
+		}
+	}
+}
@@ -1,3 +1,4 @@
+//go:generate go run ../../internal/cmd/binarygen -type=Header
 package mcp
 
 // Header is the structure of a MCP header
@@ -0,0 +1,326 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// jsonMessage is the JSON form of a message
+type jsonMessage struct {
+	ID     json.RawMessage `json:"id"`
+	Dir    string          `json:"dir"`
+	Fields json.RawMessage `json:"fields"`
+}
+
+// MarshalJSON returns the JSON form of msg:
+//
+//	{"id":"McpJoinGame","dir":"s2c","fields":{"RequestID":3,...}}
+//
+// Fields appear in wire order under their Go names. Registered enums are
+// written by name and other integers as numbers. [4]uint8 fields whose
+// names end in IP are written as dotted quads, while byte slices and
+// statstrings are written as hex strings. Other strings only round trip
+// if they are valid UTF-8. The fields of a *RawMessage are its hex encoded
+// Payload.
+func MarshalJSON(msg Message) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(`{"id":`)
+	if err := encodeJSON(&b, "id", reflect.ValueOf(msg.ID())); err != nil {
+		return nil, err
+	}
+	b.WriteString(`,"dir":`)
+	b.WriteString(strconv.Quote(msg.Direction().String()))
+	b.WriteString(`,"fields":`)
+
+	var err error
+	switch m := msg.(type) {
+	case *RawMessage:
+		err = encodeRawJSON(&b, m.Payload)
+	case RawMessage:
+		err = encodeRawJSON(&b, m.Payload)
+	default:
+		err = encodeJSON(&b, "", reflect.Indirect(reflect.ValueOf(msg)))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// UnmarshalJSON returns a pointer to the message described by the JSON
+// form in data. Omitted fields are left zero. IDs without a registered
+// message yield a *RawMessage.
+func UnmarshalJSON(data []byte) (Message, error) {
+	var j jsonMessage
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, &JSONError{Reason: err.Error()}
+	}
+
+	var dir Direction
+	switch j.Dir {
+	case ClientToServer.String():
+		dir = ClientToServer
+	case ServerToClient.String():
+		dir = ServerToClient
+	default:
+		return nil, &JSONError{Field: "dir", Reason: "unknown direction " + strconv.Quote(j.Dir)}
+	}
+
+	var id MessageID
+	if err := decodeJSON(j.ID, "id", reflect.ValueOf(&id).Elem()); err != nil {
+		return nil, err
+	}
+
+	msg, err := New(dir, id)
+	if _, ok := err.(*UnknownMessageError); ok {
+		raw := &RawMessage{MessageID: id, Dir: dir}
+		if len(j.Fields) > 0 {
+			var fields struct{ Payload string }
+			if err := decodeJSON(j.Fields, "", reflect.ValueOf(&fields).Elem()); err != nil {
+				return nil, err
+			}
+			if raw.Payload, err = decodeHex("Payload", fields.Payload); err != nil {
+				return nil, err
+			}
+		}
+		return raw, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(j.Fields) > 0 {
+		if err := decodeJSON(j.Fields, "", reflect.ValueOf(msg).Elem()); err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
+
+// JSONToFrame returns the wire frame for the message described by the
+// JSON form in data.
+func JSONToFrame(data []byte) ([]byte, error) {
+	msg, err := UnmarshalJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return MarshalMessage(msg)
+}
+
+func encodeRawJSON(b *bytes.Buffer, payload []byte) error {
+	b.WriteString(`{"Payload":`)
+	b.WriteString(strconv.Quote(hex.EncodeToString(payload)))
+	b.WriteByte('}')
+	return nil
+}
+
+// encodeJSON appends the JSON form of v, the value of the field called
+// name, to b.
+func encodeJSON(b *bytes.Buffer, name string, v reflect.Value) error {
+	if isEnum(v.Type()) {
+		if s, ok := enumName(v); ok {
+			b.WriteString(strconv.Quote(s))
+		} else {
+			b.WriteString(strconv.FormatUint(v.Uint(), 10))
+		}
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		b.WriteByte('{')
+		for i := 0; i < v.NumField(); i++ {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			f := v.Type().Field(i)
+			b.WriteString(strconv.Quote(f.Name))
+			b.WriteByte(':')
+			if err := encodeJSON(b, f.Name, v.Field(i)); err != nil {
+				return err
+			}
+		}
+		b.WriteByte('}')
+		return nil
+	case reflect.String:
+		if isStatstring(name) {
+			b.WriteString(strconv.Quote(hex.EncodeToString([]byte(v.String()))))
+			return nil
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b.WriteString(strconv.Quote(hex.EncodeToString(v.Bytes())))
+			return nil
+		}
+		fallthrough
+	case reflect.Array:
+		if isIP(name, v.Type()) {
+			ip := net.IPv4(byte(v.Index(0).Uint()), byte(v.Index(1).Uint()), byte(v.Index(2).Uint()), byte(v.Index(3).Uint()))
+			b.WriteString(strconv.Quote(ip.String()))
+			return nil
+		}
+		b.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if err := encodeJSON(b, name, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		b.WriteByte(']')
+		return nil
+	}
+
+	out, err := json.Marshal(v.Interface())
+	if err != nil {
+		return &JSONError{Field: name, Reason: err.Error()}
+	}
+	b.Write(out)
+	return nil
+}
+
+// decodeJSON stores the JSON form in data into v, the value of the field
+// called name.
+func decodeJSON(data json.RawMessage, name string, v reflect.Value) error {
+	if isEnum(v.Type()) {
+		var s string
+		if err := json.Unmarshal(data, &s); err == nil {
+			u, ok := enumValue(v.Type(), s)
+			if !ok {
+				return &JSONError{Field: name, Reason: fmt.Sprintf("unknown %s %q", v.Type().Name(), s)}
+			}
+			v.SetUint(u)
+			return nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return &JSONError{Field: name, Reason: err.Error()}
+		}
+		for key, value := range fields {
+			f, ok := v.Type().FieldByName(key)
+			if !ok || len(f.Index) != 1 {
+				return &JSONError{Field: join(name, key), Reason: "unknown field"}
+			}
+			if err := decodeJSON(value, join(name, key), v.FieldByIndex(f.Index)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.String:
+		if isStatstring(name) {
+			var s string
+			if err := json.Unmarshal(data, &s); err != nil {
+				return &JSONError{Field: name, Reason: err.Error()}
+			}
+			b, err := decodeHex(name, s)
+			if err != nil {
+				return err
+			}
+			v.SetString(string(b))
+			return nil
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			var s string
+			if err := json.Unmarshal(data, &s); err != nil {
+				return &JSONError{Field: name, Reason: err.Error()}
+			}
+			b, err := decodeHex(name, s)
+			if err != nil {
+				return err
+			}
+			v.SetBytes(b)
+			return nil
+		}
+
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return &JSONError{Field: name, Reason: err.Error()}
+		}
+		v.Set(reflect.Zero(v.Type()))
+		if len(elems) > 0 {
+			v.Set(reflect.MakeSlice(v.Type(), len(elems), len(elems)))
+		}
+		for i, e := range elems {
+			if err := decodeJSON(e, fmt.Sprintf("%s[%d]", name, i), v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Array:
+		if isIP(name, v.Type()) {
+			var s string
+			if err := json.Unmarshal(data, &s); err != nil {
+				return &JSONError{Field: name, Reason: err.Error()}
+			}
+			ip := net.ParseIP(s).To4()
+			if ip == nil {
+				return &JSONError{Field: name, Reason: fmt.Sprintf("invalid IPv4 address %q", s)}
+			}
+			reflect.Copy(v, reflect.ValueOf([]byte(ip)))
+			return nil
+		}
+
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return &JSONError{Field: name, Reason: err.Error()}
+		}
+		if len(elems) > v.Len() {
+			return &JSONError{Field: name, Reason: fmt.Sprintf("%d elements for array of %d", len(elems), v.Len())}
+		}
+		v.Set(reflect.Zero(v.Type()))
+		for i, e := range elems {
+			if err := decodeJSON(e, fmt.Sprintf("%s[%d]", name, i), v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := json.Unmarshal(data, v.Addr().Interface()); err != nil {
+		return &JSONError{Field: name, Reason: err.Error()}
+	}
+	return nil
+}
+
+func decodeHex(name, s string) ([]byte, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, &JSONError{Field: name, Reason: err.Error()}
+	}
+	if len(b) == 0 {
+		return nil, nil
+	}
+	return b, nil
+}
+
+// isIP reports whether the field called name of type t holds an IPv4
+// address.
+func isIP(name string, t reflect.Type) bool {
+	return strings.HasSuffix(name, "IP") && t.Kind() == reflect.Array && t.Len() == 4 && t.Elem().Kind() == reflect.Uint8
+}
+
+// isStatstring reports whether the field called name holds a statstring.
+func isStatstring(name string) bool {
+	return name == "Statstring" || strings.HasSuffix(name, ".Statstring")
+}
+
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
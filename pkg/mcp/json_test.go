@@ -0,0 +1,179 @@
+package mcp_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/client"
+)
+
+func TestJSONCorpus(t *testing.T) {
+	for _, tc := range corpus {
+		frame := readVector(t, tc.dir, tc.file)
+
+		data, err := mcp.MarshalJSON(tc.want)
+		if err != nil {
+			t.Errorf("%s/%s: %s", tc.dir, tc.file, err)
+			continue
+		}
+		got, err := mcp.UnmarshalJSON(data)
+		if err != nil {
+			t.Errorf("%s/%s: %s\n%s", tc.dir, tc.file, err, data)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s/%s: decoded\n%#v\nwant\n%#v", tc.dir, tc.file, got, tc.want)
+		}
+
+		b, err := mcp.JSONToFrame(data)
+		if err != nil {
+			t.Errorf("%s/%s: %s", tc.dir, tc.file, err)
+		} else if !bytes.Equal(b, frame) {
+			t.Errorf("%s/%s: frame\n% x\nwant\n% x", tc.dir, tc.file, b, frame)
+		}
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		dir  mcp.Direction
+		file string
+		want string
+	}{
+		{mcp.ServerToClient, "joingame", `{"id":"McpJoinGame","dir":"s2c","fields":{"RequestID":3,"GameToken":49,"Unknown":0,"GameServerIP":"192.168.1.20","GameHash":1787780641,"Result":"JoinGameSuccess"}}`},
+		{mcp.ClientToServer, "charcreate", `{"id":"McpCharCreate","dir":"c2s","fields":{"Class":"Barbarian","Flags":36,"Name":"Conan"}}`},
+		{mcp.ServerToClient, "chardelete", `{"id":"McpCharDelete","dir":"s2c","fields":{"Result":"CharDeleteNotFound"}}`},
+	}
+	for _, tc := range tests {
+		msg, err := mcp.Decode(tc.dir, readVector(t, tc.dir, tc.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := mcp.MarshalJSON(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("%s/%s: got\n%s\nwant\n%s", tc.dir, tc.file, got, tc.want)
+		}
+	}
+}
+
+func TestUnmarshalJSONHandCrafted(t *testing.T) {
+	msg, err := mcp.UnmarshalJSON([]byte(`{
+		"id": "McpCreateGame",
+		"dir": "c2s",
+		"fields": {"RequestID": 7, "Difficulty": "Nightmare", "Name": "cows", "MaxPlayers": 4}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &client.CreateGame{RequestID: 7, Difficulty: mcp.DifficultyNightmare, Name: "cows", MaxPlayers: 4}
+	if !reflect.DeepEqual(msg, want) {
+		t.Errorf("got %#v, want %#v", msg, want)
+	}
+}
+
+func TestJSONRawMessage(t *testing.T) {
+	raw := &mcp.RawMessage{MessageID: 0x0b, Dir: mcp.ServerToClient, Payload: []byte{0x01, 0xff}}
+
+	data, err := mcp.MarshalJSON(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"id":11,"dir":"s2c","fields":{"Payload":"01ff"}}`; string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+
+	frame, err := mcp.JSONToFrame(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0x05, 0x00, 0x0b, 0x01, 0xff}; !bytes.Equal(frame, want) {
+		t.Errorf("got % x, want % x", frame, want)
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	tests := []struct {
+		in    string
+		field string
+	}{
+		{`{"id":"McpCharLogon","dir":"up","fields":{}}`, "dir"},
+		{`{"id":"McpNothing","dir":"c2s","fields":{}}`, "id"},
+		{`{"id":"McpCharLogon","dir":"c2s","fields":{"Name":"Conan"}}`, "Name"},
+		{`{"id":"McpCharCreate","dir":"c2s","fields":{"Class":"Bard"}}`, "Class"},
+		{`{"id":"McpJoinGame","dir":"s2c","fields":{"GameServerIP":"::1"}}`, "GameServerIP"},
+		{`{"id":"McpCharList2","dir":"s2c","fields":{"Characters":[{"Statstring":"zz"}]}}`, "Characters[0].Statstring"},
+	}
+	for _, tc := range tests {
+		_, err := mcp.UnmarshalJSON([]byte(tc.in))
+		e, ok := err.(*mcp.JSONError)
+		if !ok {
+			t.Errorf("%s: got %v, want *JSONError", tc.in, err)
+			continue
+		}
+		if e.Field != tc.field {
+			t.Errorf("%s: error for field %q, want %q", tc.in, e.Field, tc.field)
+		}
+	}
+}
+
+// TestJSONEnumNames round-trips every named value of each enumerated field
+// of the registered messages by name.
+func TestJSONEnumNames(t *testing.T) {
+	seen := map[string]bool{}
+	for _, dir := range []mcp.Direction{mcp.ClientToServer, mcp.ServerToClient} {
+		for id := 0; id <= 0xff; id++ {
+			msg, err := mcp.New(dir, mcp.MessageID(id))
+			if err != nil {
+				continue
+			}
+			st := reflect.TypeOf(msg).Elem()
+			for i := 0; i < st.NumField(); i++ {
+				f := st.Field(i)
+				values := mcp.EnumValues(f.Type)
+				if values == nil {
+					continue
+				}
+				seen[f.Type.Name()] = true
+
+				for v, name := range values {
+					sent, _ := mcp.New(dir, mcp.MessageID(id))
+					reflect.ValueOf(sent).Elem().Field(i).SetUint(v)
+					if s := reflect.ValueOf(sent).Elem().Field(i).Interface().(fmt.Stringer).String(); s != name {
+						t.Errorf("%s %d registered as %s", s, v, name)
+					}
+
+					data, err := mcp.MarshalJSON(sent)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if want := fmt.Sprintf("%q:%q", f.Name, name); !bytes.Contains(data, []byte(want)) {
+						t.Errorf("%s: %s does not contain %s", st, data, want)
+					}
+					got, err := mcp.UnmarshalJSON(data)
+					if err != nil {
+						t.Fatalf("%s: %s", data, err)
+					}
+					if !reflect.DeepEqual(got, sent) {
+						t.Errorf("%s: decoded %#v", data, got)
+					}
+				}
+			}
+		}
+	}
+
+	for _, name := range []string{
+		"CharacterClass", "Difficulty", "StartupResult", "CharCreateResult",
+		"CreateGameResult", "JoinGameResult", "CharLogonResult",
+		"CharDeleteResult", "CharRankResult", "CharUpgradeResult",
+	} {
+		if !seen[name] {
+			t.Errorf("no message field of type %s", name)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package mcp
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/samlitowitz/bnet-encoding/pkg/encoding/bnet"
+)
+
+// binaryAppender is implemented by messages with generated encoders
+type binaryAppender interface {
+	AppendBinary(b []byte) ([]byte, error)
+}
+
+// Marshal returns the complete wire frame for msg sent as message id. The
+// header length is computed from the encoded payload.
+func Marshal(id MessageID, msg interface{}) ([]byte, error) {
+	return Append(nil, id, msg)
+}
+
+// MarshalMessage returns the complete wire frame for msg. The header is
+// filled from msg.ID and the encoded payload.
+func MarshalMessage(msg Message) ([]byte, error) {
+	return Append(nil, msg.ID(), msg)
+}
+
+// Append appends the complete wire frame for msg sent as message id to b.
+// Messages with generated encoders are appended without reflection or
+// intermediate allocations.
+func Append(b []byte, id MessageID, msg interface{}) ([]byte, error) {
+	start := len(b)
+	b, err := Header{MessageID: id}.AppendBinary(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if a, ok := msg.(binaryAppender); ok {
+		b, err = a.AppendBinary(b)
+	} else {
+		var payload []byte
+		payload, err = bnet.Marshal(msg)
+		b = append(b, payload...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	length := len(b) - start
+	if length > math.MaxUint16 {
+		return nil, &InvalidLengthError{Length: length}
+	}
+	binary.LittleEndian.PutUint16(b[start:], uint16(length))
+	return b, nil
+}
+
+// Unmarshal parses payload into the message pointed to by msg. Messages
+// implementing encoding.BinaryUnmarshaler or bnet.Unmarshaler decode
+// themselves. Malformed payloads always produce an error, never a panic.
+func Unmarshal(payload []byte, msg interface{}) error {
+	switch u := msg.(type) {
+	case encoding.BinaryUnmarshaler:
+		return u.UnmarshalBinary(payload)
+	case bnet.Unmarshaler:
+		return u.UnmarshalBNet(payload)
+	}
+	return unmarshalReflect(payload, msg)
+}
+
+// unmarshalReflect decodes payload with the reflective bnet decoder, which
+// panics instead of failing on some truncated input.
+func unmarshalReflect(payload []byte, msg interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &MalformedPayloadError{Type: fmt.Sprintf("%T", msg), Reason: fmt.Sprint(r)}
+		}
+	}()
+	return bnet.Unmarshal(payload, msg)
+}
@@ -0,0 +1,16 @@
+package mcp
+
+import "testing"
+
+type reflectiveMOTD struct {
+	Unknown uint8
+	Message string
+}
+
+func TestUnmarshalUnterminatedString(t *testing.T) {
+	var m reflectiveMOTD
+	err := Unmarshal([]byte("\x00Welcome"), &m)
+	if _, ok := err.(*MalformedPayloadError); !ok {
+		t.Fatalf("got %v, want *MalformedPayloadError", err)
+	}
+}
@@ -0,0 +1,9 @@
+package mcp
+
+// Message is implemented by every MCP request and response
+type Message interface {
+	// ID returns the message ID sent in the header
+	ID() MessageID
+	// Direction returns the direction in which the message is sent
+	Direction() Direction
+}
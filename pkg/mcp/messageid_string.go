@@ -4,14 +4,34 @@ package mcp
 
 import "strconv"
 
-const _MessageID_name = "McpStartupMcpCharCreateMcpCreateGameMcpJoinGameMcpGameListMcpGameInfoMcpCharLogonMcpCharDeleteMcpRequestLadderDataMcpMOTDMcpCancelGameCreateMcpCreateQueueMcpCharRankMcpCharListMcpCharUpgradeMcpCharList2"
+const (
+	_MessageID_name_0 = "McpStartupMcpCharCreateMcpCreateGameMcpJoinGameMcpGameListMcpGameInfoMcpCharLogon"
+	_MessageID_name_1 = "McpCharDelete"
+	_MessageID_name_2 = "McpRequestLadderDataMcpMOTDMcpCancelGameCreateMcpCreateQueue"
+	_MessageID_name_3 = "McpCharRankMcpCharListMcpCharUpgradeMcpCharList2"
+)
 
-var _MessageID_index = [...]uint8{0, 10, 23, 36, 47, 58, 69, 81, 94, 114, 121, 140, 154, 165, 176, 190, 202}
+var (
+	_MessageID_index_0 = [...]uint8{0, 10, 23, 36, 47, 58, 69, 81}
+	_MessageID_index_1 = [...]uint8{0, 13}
+	_MessageID_index_2 = [...]uint8{0, 20, 27, 46, 60}
+	_MessageID_index_3 = [...]uint8{0, 11, 22, 36, 48}
+)
 
 func (i MessageID) String() string {
-	i -= 1
-	if i >= MessageID(len(_MessageID_index)-1) {
-		return "MessageID(" + strconv.FormatInt(int64(i+1), 10) + ")"
+	switch {
+	case 1 <= i && i <= 7:
+		i -= 1
+		return _MessageID_name_0[_MessageID_index_0[i]:_MessageID_index_0[i+1]]
+	case i == 10:
+		return _MessageID_name_1
+	case 17 <= i && i <= 20:
+		i -= 17
+		return _MessageID_name_2[_MessageID_index_2[i]:_MessageID_index_2[i+1]]
+	case 22 <= i && i <= 25:
+		i -= 22
+		return _MessageID_name_3[_MessageID_index_3[i]:_MessageID_index_3[i+1]]
+	default:
+		return "MessageID(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
-	return _MessageID_name[_MessageID_index[i]:_MessageID_index[i+1]]
 }
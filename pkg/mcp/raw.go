@@ -0,0 +1,43 @@
+package mcp
+
+// RawMessage is a message whose payload is kept undecoded. Reader returns
+// it for message IDs without a registered type, and Writer sends its
+// payload verbatim.
+type RawMessage struct {
+	MessageID MessageID
+	Dir       Direction
+	Payload   []byte
+}
+
+// ID returns the message ID of the raw message
+func (m RawMessage) ID() MessageID {
+	return m.MessageID
+}
+
+// Direction returns the direction in which the raw message is sent
+func (m RawMessage) Direction() Direction {
+	return m.Dir
+}
+
+// AppendBinary appends the payload of m to b.
+func (m RawMessage) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, m.Payload...), nil
+}
+
+// MarshalBinary returns a copy of the payload of m.
+func (m RawMessage) MarshalBinary() ([]byte, error) {
+	return m.AppendBinary(nil)
+}
+
+// UnmarshalBinary stores a copy of data as the payload of m.
+func (m *RawMessage) UnmarshalBinary(data []byte) error {
+	_, err := m.DecodeBinary(data)
+	return err
+}
+
+// DecodeBinary stores a copy of data as the payload of m and returns
+// len(data).
+func (m *RawMessage) DecodeBinary(data []byte) (int, error) {
+	m.Payload = append([]byte(nil), data...)
+	return len(data), nil
+}
@@ -0,0 +1,45 @@
+package mcp_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/client"
+)
+
+func TestRawMessagePassthrough(t *testing.T) {
+	var stream bytes.Buffer
+	stream.Write([]byte{0x06, 0x00, 0x09, 0x01, 0x02, 0x03})
+	stream.Write(readVector(t, mcp.ClientToServer, "charlogon"))
+	stream.Write([]byte{0x03, 0x00, 0x0b})
+	stream.Write([]byte{0x04, 0x00, 0xf0, 0xff})
+	in := append([]byte{}, stream.Bytes()...)
+
+	want := []mcp.Message{
+		&mcp.RawMessage{MessageID: 0x09, Dir: mcp.ClientToServer, Payload: []byte{0x01, 0x02, 0x03}},
+		&client.CharLogon{CharacterName: "Conan"},
+		&mcp.RawMessage{MessageID: 0x0b, Dir: mcp.ClientToServer, Payload: []byte{}},
+		&mcp.RawMessage{MessageID: 0xf0, Dir: mcp.ClientToServer, Payload: []byte{0xff}},
+	}
+
+	var out bytes.Buffer
+	r := mcp.NewReader(&stream)
+	w := mcp.NewWriter(&out)
+	for _, m := range want {
+		got, err := r.ReadMessage(mcp.ClientToServer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, m) {
+			t.Errorf("read %#v, want %#v", got, m)
+		}
+		if err := w.WriteMessage(got); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bytes.Equal(out.Bytes(), in) {
+		t.Errorf("wrote\n% x\nwant\n% x", out.Bytes(), in)
+	}
+}
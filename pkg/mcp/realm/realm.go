@@ -0,0 +1,55 @@
+// Package realm connects a Battle.net logon to the realm server it
+// refers the client to.
+package realm
+
+import (
+	"fmt"
+	"net"
+
+	bncs "github.com/samlitowitz/bnet-bncs/pkg/bncs/server"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/client"
+)
+
+// Failures reported in the MCPStatus of a SID_LOGONREALMEX response
+const (
+	StatusUnavailable uint32 = 0x80000001
+	StatusLogonFailed uint32 = 0x80000002
+)
+
+// A LogonError occurs when a SID_LOGONREALMEX response reports a failure
+// instead of a realm server.
+type LogonError struct {
+	Status uint32
+}
+
+func (e *LogonError) Error() string {
+	switch e.Status {
+	case StatusUnavailable:
+		return "realm: realm unavailable"
+	case StatusLogonFailed:
+		return "realm: realm logon failed"
+	}
+	return fmt.Sprintf("realm: logon failed with status 0x%08x", e.Status)
+}
+
+// FromLogonRealmEx returns the address of the realm server named by a
+// SID_LOGONREALMEX response and the MCP_STARTUP request to send it once
+// connected. It returns a *LogonError if the response reports a failure.
+func FromLogonRealmEx(r *bncs.LogonRealmEx) (*net.TCPAddr, *client.Startup, error) {
+	if r.MCPStatus&0x80000000 != 0 {
+		return nil, nil, &LogonError{Status: r.MCPStatus}
+	}
+
+	addr := &net.TCPAddr{
+		IP:   net.IPv4(r.IP[0], r.IP[1], r.IP[2], r.IP[3]),
+		Port: int(r.Port),
+	}
+	startup := &client.Startup{
+		MCPCookie:  r.MCPCookie,
+		MCPStatus:  r.MCPStatus,
+		Chunk1:     r.Chunk1,
+		Chunk2:     r.Chunk2,
+		UniqueName: r.Name,
+	}
+	return addr, startup, nil
+}
@@ -0,0 +1,57 @@
+package realm
+
+import (
+	"reflect"
+	"testing"
+
+	bncs "github.com/samlitowitz/bnet-bncs/pkg/bncs/server"
+	"github.com/samlitowitz/bnet-encoding/pkg/encoding/bnet"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/client"
+)
+
+func TestFromLogonRealmEx(t *testing.T) {
+	// SID_LOGONREALMEX payload referring to 192.168.1.20:6112
+	data := []byte{
+		0x73, 0x0f, 0x2b, 0x8c, // MCPCookie
+		0x00, 0x00, 0x00, 0x00, // MCPStatus
+		0xd5, 0xa1, 0x7c, 0x1f, 0xb4, 0xd2, 0xe1, 0x0a, // Chunk1
+		0xc0, 0xa8, 0x01, 0x14, // IP
+		0x17, 0xe0, // Port, big endian
+		0x00, 0x00, // Unknown1
+	}
+	for i := 0; i < 12; i++ {
+		data = append(data, byte(i), 0x00, 0x00, 0x00)
+	}
+	data = append(data, "Conan\x00"...)
+
+	var r bncs.LogonRealmEx
+	if err := bnet.Unmarshal(data, &r); err != nil {
+		t.Fatal(err)
+	}
+
+	addr, startup, err := FromLogonRealmEx(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr.String() != "192.168.1.20:6112" {
+		t.Errorf("address %s, want 192.168.1.20:6112", addr)
+	}
+
+	want := &client.Startup{
+		MCPCookie:  0x8c2b0f73,
+		Chunk1:     [2]uint32{0x1f7ca1d5, 0x0ae1d2b4},
+		Chunk2:     [12]uint32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+		UniqueName: "Conan",
+	}
+	if !reflect.DeepEqual(startup, want) {
+		t.Errorf("got %#v, want %#v", startup, want)
+	}
+}
+
+func TestFromLogonRealmExFailure(t *testing.T) {
+	r := &bncs.LogonRealmEx{MCPCookie: 1, MCPStatus: StatusLogonFailed}
+	_, _, err := FromLogonRealmEx(r)
+	if e, ok := err.(*LogonError); !ok || e.Status != StatusLogonFailed {
+		t.Errorf("got %v, want *LogonError with status %#x", err, StatusLogonFailed)
+	}
+}
@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"reflect"
+	"sync"
+)
+
+type registryKey struct {
+	dir Direction
+	id  MessageID
+}
+
+var registry struct {
+	mu    sync.RWMutex
+	types map[registryKey]reflect.Type
+}
+
+// Register records the type of msg as the message sent in its direction
+// with its ID. It is intended to be called from the init function of the
+// packages defining messages. Register panics if the ID is already
+// registered for the direction.
+func Register(msg Message) {
+	dir, id := msg.Direction(), msg.ID()
+	t := reflect.TypeOf(msg)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if registry.types == nil {
+		registry.types = make(map[registryKey]reflect.Type)
+	}
+	k := registryKey{dir: dir, id: id}
+	if _, ok := registry.types[k]; ok {
+		panic("mcp: Register called twice for " + dir.String() + " " + id.String())
+	}
+	registry.types[k] = t
+}
+
+// New returns a pointer to a new zero value of the message registered
+// for dir and id.
+func New(dir Direction, id MessageID) (Message, error) {
+	registry.mu.RLock()
+	t, ok := registry.types[registryKey{dir: dir, id: id}]
+	registry.mu.RUnlock()
+
+	if !ok {
+		return nil, &UnknownMessageError{Direction: dir, MessageID: id}
+	}
+	return reflect.New(t).Interface().(Message), nil
+}
+
+// Decode parses a complete wire frame sent in dir and returns a pointer
+// to the registered message it contains. Bytes following the frame are
+// ignored.
+func Decode(dir Direction, frame []byte) (Message, error) {
+	f, err := splitFrame(frame)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeFrame(dir, f)
+}
+
+// splitFrame returns the frame at the start of frame. Bytes following the
+// length declared by its header are excluded from the payload.
+func splitFrame(frame []byte) (*Frame, error) {
+	if len(frame) < HeaderLength {
+		return nil, &InvalidLengthError{Length: len(frame)}
+	}
+
+	f := &Frame{}
+	if err := f.Header.UnmarshalBinary(frame[:HeaderLength]); err != nil {
+		return nil, err
+	}
+	if f.Header.Length < HeaderLength || int(f.Header.Length) > len(frame) {
+		return nil, &InvalidLengthError{Length: int(f.Header.Length)}
+	}
+	f.Payload = frame[HeaderLength:f.Header.Length]
+	return f, nil
+}
+
+// DecodeFrame returns a pointer to the registered message contained in f.
+func DecodeFrame(dir Direction, f *Frame) (Message, error) {
+	msg, err := New(dir, f.Header.MessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Unmarshal(f.Payload, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
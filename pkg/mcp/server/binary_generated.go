@@ -0,0 +1,866 @@
+// Code generated by "binarygen -type=Startup,CharCreate,CreateGame,JoinGame,GameList,GameInfo,CharLogon,CharDelete,RequestLadderData,MOTD,CreateQueue,CharRank,CharList,CharListCharacter,CharUpgrade,CharList2,CharList2Character,LadderEntry"; DO NOT EDIT.
+
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/samlitowitz/bnet-encoding/pkg/encoding/bnet"
+)
+
+// AppendBinary appends the wire encoding of x to b.
+func (x Startup) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.Result), byte(x.Result>>8), byte(x.Result>>16), byte(x.Result>>24))
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x Startup) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *Startup) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *Startup) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *Startup) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "Startup", Field: "Result"}
+	}
+	x.Result = StartupResult(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharCreate) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.Result), byte(x.Result>>8), byte(x.Result>>16), byte(x.Result>>24))
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharCreate) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharCreate) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharCreate) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharCreate) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CharCreate", Field: "Result"}
+	}
+	x.Result = CharCreateResult(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CreateGame) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.RequestID), byte(x.RequestID>>8))
+	b = append(b, byte(x.GameToken), byte(x.GameToken>>8))
+	b = append(b, byte(x.Unknown), byte(x.Unknown>>8))
+	b = append(b, byte(x.Result), byte(x.Result>>8), byte(x.Result>>16), byte(x.Result>>24))
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CreateGame) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CreateGame) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CreateGame) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CreateGame) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "CreateGame", Field: "RequestID"}
+	}
+	x.RequestID = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "CreateGame", Field: "GameToken"}
+	}
+	x.GameToken = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "CreateGame", Field: "Unknown"}
+	}
+	x.Unknown = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CreateGame", Field: "Result"}
+	}
+	x.Result = CreateGameResult(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x JoinGame) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.RequestID), byte(x.RequestID>>8))
+	b = append(b, byte(x.GameToken), byte(x.GameToken>>8))
+	b = append(b, byte(x.Unknown), byte(x.Unknown>>8))
+	b = append(b, x.GameServerIP[:]...)
+	b = append(b, byte(x.GameHash), byte(x.GameHash>>8), byte(x.GameHash>>16), byte(x.GameHash>>24))
+	b = append(b, byte(x.Result), byte(x.Result>>8), byte(x.Result>>16), byte(x.Result>>24))
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x JoinGame) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *JoinGame) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *JoinGame) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *JoinGame) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "JoinGame", Field: "RequestID"}
+	}
+	x.RequestID = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "JoinGame", Field: "GameToken"}
+	}
+	x.GameToken = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "JoinGame", Field: "Unknown"}
+	}
+	x.Unknown = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "JoinGame", Field: "GameServerIP"}
+	}
+	copy(x.GameServerIP[:], data[off:])
+	off += 4
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "JoinGame", Field: "GameHash"}
+	}
+	x.GameHash = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "JoinGame", Field: "Result"}
+	}
+	x.Result = JoinGameResult(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x GameList) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.RequestID), byte(x.RequestID>>8))
+	b = append(b, byte(x.Index), byte(x.Index>>8), byte(x.Index>>16), byte(x.Index>>24))
+	b = append(b, byte(x.PlayerCount))
+	b = append(b, byte(x.Status), byte(x.Status>>8), byte(x.Status>>16), byte(x.Status>>24))
+	b = append(b, x.Name...)
+	b = append(b, 0x00)
+	b = append(b, x.Description...)
+	b = append(b, 0x00)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x GameList) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *GameList) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *GameList) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *GameList) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "GameList", Field: "RequestID"}
+	}
+	x.RequestID = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "GameList", Field: "Index"}
+	}
+	x.Index = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+	if len(data)-off < 1 {
+		return off, &bnet.IndexOutOfRangeError{N: 1, Offset: int64(off), Struct: "GameList", Field: "PlayerCount"}
+	}
+	x.PlayerCount = data[off]
+	off++
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "GameList", Field: "Status"}
+	}
+	x.Status = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+	n1 := bytes.IndexByte(data[off:], 0x00)
+	if n1 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "GameList", Field: "Name"}
+	}
+	x.Name = string(data[off : off+n1])
+	off += n1 + 1
+	n2 := bytes.IndexByte(data[off:], 0x00)
+	if n2 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "GameList", Field: "Description"}
+	}
+	x.Description = string(data[off : off+n2])
+	off += n2 + 1
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x GameInfo) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.RequestID), byte(x.RequestID>>8))
+	b = append(b, byte(x.Status), byte(x.Status>>8), byte(x.Status>>16), byte(x.Status>>24))
+	b = append(b, byte(x.Uptime), byte(x.Uptime>>8), byte(x.Uptime>>16), byte(x.Uptime>>24))
+	b = append(b, byte(x.LevelRestrictionLevel))
+	b = append(b, byte(x.LevelRestrictionDifference))
+	b = append(b, byte(x.MaxPlayers))
+	b = append(b, byte(x.CharacterCount))
+	b = append(b, x.CharacterClasses[:]...)
+	b = append(b, x.CharacterLevels[:]...)
+	b = append(b, x.Description...)
+	b = append(b, 0x00)
+	b = append(b, x.CharacterNames...)
+	b = append(b, 0x00)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x GameInfo) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *GameInfo) UnmarshalBinary(data []byte) error {
+	return x.UnmarshalBNet(data)
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharLogon) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.Result), byte(x.Result>>8), byte(x.Result>>16), byte(x.Result>>24))
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharLogon) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharLogon) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharLogon) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharLogon) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CharLogon", Field: "Result"}
+	}
+	x.Result = CharLogonResult(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharDelete) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.Result), byte(x.Result>>8), byte(x.Result>>16), byte(x.Result>>24))
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharDelete) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharDelete) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharDelete) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharDelete) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CharDelete", Field: "Result"}
+	}
+	x.Result = CharDeleteResult(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x RequestLadderData) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.LadderType))
+	b = append(b, byte(x.TotalSize), byte(x.TotalSize>>8))
+	b = append(b, byte(x.ChunkSize), byte(x.ChunkSize>>8))
+	b = append(b, byte(x.RemainingSize), byte(x.RemainingSize>>8))
+	b = append(b, byte(x.FirstRank), byte(x.FirstRank>>8))
+	b = append(b, byte(x.Unknown), byte(x.Unknown>>8))
+	b = append(b, x.Data...)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x RequestLadderData) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *RequestLadderData) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *RequestLadderData) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *RequestLadderData) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 1 {
+		return off, &bnet.IndexOutOfRangeError{N: 1, Offset: int64(off), Struct: "RequestLadderData", Field: "LadderType"}
+	}
+	x.LadderType = data[off]
+	off++
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "RequestLadderData", Field: "TotalSize"}
+	}
+	x.TotalSize = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "RequestLadderData", Field: "ChunkSize"}
+	}
+	x.ChunkSize = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	saveRLDChunk := int(x.ChunkSize)
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "RequestLadderData", Field: "RemainingSize"}
+	}
+	x.RemainingSize = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "RequestLadderData", Field: "FirstRank"}
+	}
+	x.FirstRank = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "RequestLadderData", Field: "Unknown"}
+	}
+	x.Unknown = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if saveRLDChunk > len(data)-off {
+		return off, &bnet.IndexOutOfRangeError{N: int64(saveRLDChunk), Offset: int64(off), Struct: "RequestLadderData", Field: "Data"}
+	}
+	x.Data = nil
+	if saveRLDChunk > 0 {
+		x.Data = make([]uint8, saveRLDChunk)
+		off += copy(x.Data, data[off:])
+	}
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x MOTD) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.Unknown))
+	b = append(b, x.Message...)
+	b = append(b, 0x00)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x MOTD) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *MOTD) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *MOTD) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *MOTD) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 1 {
+		return off, &bnet.IndexOutOfRangeError{N: 1, Offset: int64(off), Struct: "MOTD", Field: "Unknown"}
+	}
+	x.Unknown = data[off]
+	off++
+	n3 := bytes.IndexByte(data[off:], 0x00)
+	if n3 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "MOTD", Field: "Message"}
+	}
+	x.Message = string(data[off : off+n3])
+	off += n3 + 1
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CreateQueue) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.Position), byte(x.Position>>8), byte(x.Position>>16), byte(x.Position>>24))
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CreateQueue) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CreateQueue) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CreateQueue) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CreateQueue) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CreateQueue", Field: "Position"}
+	}
+	x.Position = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharRank) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.Result), byte(x.Result>>8), byte(x.Result>>16), byte(x.Result>>24))
+	b = append(b, byte(x.Rank), byte(x.Rank>>8), byte(x.Rank>>16), byte(x.Rank>>24))
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharRank) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharRank) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharRank) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharRank) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CharRank", Field: "Result"}
+	}
+	x.Result = CharRankResult(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CharRank", Field: "Rank"}
+	}
+	x.Rank = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharList) AppendBinary(b []byte) ([]byte, error) {
+	var err error
+	b = append(b, byte(x.RequestCount), byte(x.RequestCount>>8))
+	b = append(b, byte(x.ExistCount), byte(x.ExistCount>>8), byte(x.ExistCount>>16), byte(x.ExistCount>>24))
+	b = append(b, byte(x.ReturnedCount), byte(x.ReturnedCount>>8))
+	for i4 := range x.Characters {
+		if b, err = x.Characters[i4].AppendBinary(b); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharList) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharList) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharList) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharList) decodeBinary(data []byte, off int) (int, error) {
+	var err error
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "CharList", Field: "RequestCount"}
+	}
+	x.RequestCount = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CharList", Field: "ExistCount"}
+	}
+	x.ExistCount = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "CharList", Field: "ReturnedCount"}
+	}
+	x.ReturnedCount = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	saveCLReturned := int(x.ReturnedCount)
+	if saveCLReturned > len(data)-off {
+		return off, &bnet.IndexOutOfRangeError{N: int64(saveCLReturned), Offset: int64(off), Struct: "CharList", Field: "Characters"}
+	}
+	x.Characters = nil
+	if saveCLReturned > 0 {
+		x.Characters = make([]CharListCharacter, saveCLReturned)
+		for i5 := range x.Characters {
+			if off, err = x.Characters[i5].decodeBinary(data, off); err != nil {
+				return off, err
+			}
+		}
+	}
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharListCharacter) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, x.Name...)
+	b = append(b, 0x00)
+	b = append(b, x.Statstring...)
+	b = append(b, 0x00)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharListCharacter) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharListCharacter) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharListCharacter) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharListCharacter) decodeBinary(data []byte, off int) (int, error) {
+	n6 := bytes.IndexByte(data[off:], 0x00)
+	if n6 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "CharListCharacter", Field: "Name"}
+	}
+	x.Name = string(data[off : off+n6])
+	off += n6 + 1
+	n7 := bytes.IndexByte(data[off:], 0x00)
+	if n7 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "CharListCharacter", Field: "Statstring"}
+	}
+	x.Statstring = string(data[off : off+n7])
+	off += n7 + 1
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharUpgrade) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.Result), byte(x.Result>>8), byte(x.Result>>16), byte(x.Result>>24))
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharUpgrade) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharUpgrade) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharUpgrade) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharUpgrade) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CharUpgrade", Field: "Result"}
+	}
+	x.Result = CharUpgradeResult(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharList2) AppendBinary(b []byte) ([]byte, error) {
+	var err error
+	b = append(b, byte(x.RequestCount), byte(x.RequestCount>>8))
+	b = append(b, byte(x.ExistCount), byte(x.ExistCount>>8), byte(x.ExistCount>>16), byte(x.ExistCount>>24))
+	b = append(b, byte(x.ReturnedCount), byte(x.ReturnedCount>>8))
+	for i8 := range x.Characters {
+		if b, err = x.Characters[i8].AppendBinary(b); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharList2) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharList2) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharList2) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharList2) decodeBinary(data []byte, off int) (int, error) {
+	var err error
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "CharList2", Field: "RequestCount"}
+	}
+	x.RequestCount = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CharList2", Field: "ExistCount"}
+	}
+	x.ExistCount = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "CharList2", Field: "ReturnedCount"}
+	}
+	x.ReturnedCount = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	saveCL2Returned := int(x.ReturnedCount)
+	if saveCL2Returned > len(data)-off {
+		return off, &bnet.IndexOutOfRangeError{N: int64(saveCL2Returned), Offset: int64(off), Struct: "CharList2", Field: "Characters"}
+	}
+	x.Characters = nil
+	if saveCL2Returned > 0 {
+		x.Characters = make([]CharList2Character, saveCL2Returned)
+		for i9 := range x.Characters {
+			if off, err = x.Characters[i9].decodeBinary(data, off); err != nil {
+				return off, err
+			}
+		}
+	}
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x CharList2Character) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.ExpirationDate), byte(x.ExpirationDate>>8), byte(x.ExpirationDate>>16), byte(x.ExpirationDate>>24))
+	b = append(b, x.Name...)
+	b = append(b, 0x00)
+	b = append(b, x.Statstring...)
+	b = append(b, 0x00)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x CharList2Character) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *CharList2Character) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *CharList2Character) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *CharList2Character) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 4 {
+		return off, &bnet.IndexOutOfRangeError{N: 4, Offset: int64(off), Struct: "CharList2Character", Field: "ExpirationDate"}
+	}
+	x.ExpirationDate = binary.LittleEndian.Uint32(data[off:])
+	off += 4
+	n10 := bytes.IndexByte(data[off:], 0x00)
+	if n10 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "CharList2Character", Field: "Name"}
+	}
+	x.Name = string(data[off : off+n10])
+	off += n10 + 1
+	n11 := bytes.IndexByte(data[off:], 0x00)
+	if n11 < 0 {
+		return off, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "CharList2Character", Field: "Statstring"}
+	}
+	x.Statstring = string(data[off : off+n11])
+	off += n11 + 1
+	return off, nil
+}
+
+// AppendBinary appends the wire encoding of x to b.
+func (x LadderEntry) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, byte(x.Experience), byte(x.Experience>>8), byte(x.Experience>>16), byte(x.Experience>>24), byte(x.Experience>>32), byte(x.Experience>>40), byte(x.Experience>>48), byte(x.Experience>>56))
+	b = append(b, byte(x.Status), byte(x.Status>>8))
+	b = append(b, byte(x.Level))
+	b = append(b, byte(x.Unknown))
+	b = append(b, x.Name[:]...)
+	return b, nil
+}
+
+// MarshalBinary returns the wire encoding of x.
+func (x LadderEntry) MarshalBinary() ([]byte, error) {
+	return x.AppendBinary(nil)
+}
+
+// UnmarshalBinary decodes the wire encoding in data into x.
+func (x *LadderEntry) UnmarshalBinary(data []byte) error {
+	_, err := x.decodeBinary(data, 0)
+	return err
+}
+
+// DecodeBinary decodes x from the start of data and returns the number
+// of bytes consumed.
+func (x *LadderEntry) DecodeBinary(data []byte) (int, error) {
+	return x.decodeBinary(data, 0)
+}
+
+// decodeBinary decodes x from data starting at off and returns the
+// offset following x.
+func (x *LadderEntry) decodeBinary(data []byte, off int) (int, error) {
+	if len(data)-off < 8 {
+		return off, &bnet.IndexOutOfRangeError{N: 8, Offset: int64(off), Struct: "LadderEntry", Field: "Experience"}
+	}
+	x.Experience = binary.LittleEndian.Uint64(data[off:])
+	off += 8
+	if len(data)-off < 2 {
+		return off, &bnet.IndexOutOfRangeError{N: 2, Offset: int64(off), Struct: "LadderEntry", Field: "Status"}
+	}
+	x.Status = binary.LittleEndian.Uint16(data[off:])
+	off += 2
+	if len(data)-off < 1 {
+		return off, &bnet.IndexOutOfRangeError{N: 1, Offset: int64(off), Struct: "LadderEntry", Field: "Level"}
+	}
+	x.Level = data[off]
+	off++
+	if len(data)-off < 1 {
+		return off, &bnet.IndexOutOfRangeError{N: 1, Offset: int64(off), Struct: "LadderEntry", Field: "Unknown"}
+	}
+	x.Unknown = data[off]
+	off++
+	if len(data)-off < 16 {
+		return off, &bnet.IndexOutOfRangeError{N: 16, Offset: int64(off), Struct: "LadderEntry", Field: "Name"}
+	}
+	copy(x.Name[:], data[off:])
+	off += 16
+	return off, nil
+}
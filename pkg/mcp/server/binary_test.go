@@ -0,0 +1,155 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/samlitowitz/bnet-encoding/pkg/encoding/bnet"
+)
+
+var benchGameList = GameList{
+	RequestID:   0x0004,
+	Index:       0x0000002a,
+	PlayerCount: 0x03,
+	Status:      0x00300004,
+	Name:        "baal-run-42",
+	Description: "fast baal runs",
+}
+
+var benchGameInfo = GameInfo{
+	RequestID:                  0x0004,
+	Status:                     0x00300004,
+	Uptime:                     754,
+	LevelRestrictionLevel:      0x01,
+	LevelRestrictionDifference: 0xff,
+	MaxPlayers:                 8,
+	CharacterCount:             3,
+	CharacterClasses:           [16]uint8{0x05, 0x01, 0x03},
+	CharacterLevels:            [16]uint8{0x5d, 0x57, 0x4f},
+	Description:                "fast baal runs",
+	CharacterNames:             "Conan\x00Xena\x00Valeria",
+}
+
+var benchCharList2 = CharList2{
+	RequestCount:  8,
+	ExistCount:    2,
+	ReturnedCount: 2,
+	Characters: []CharList2Character{
+		{ExpirationDate: 0x5cc2a7a0, Name: "Conan", Statstring: "\x84\x80\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\x05\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\x2a\xa0\x8a\x80\x80\xff\x80\x80"},
+		{ExpirationDate: 0x5cc2a7a0, Name: "Xena", Statstring: "\x84\x80\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01\xa0\x80\x80\x80\xff\x80\x80"},
+	},
+}
+
+func BenchmarkGameListMarshalReflect(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := bnet.Marshal(&benchGameList); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGameListAppendBinary(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		var err error
+		if buf, err = benchGameList.AppendBinary(buf[:0]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGameListUnmarshalReflect(b *testing.B) {
+	data, _ := benchGameList.MarshalBinary()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var g GameList
+		if err := bnet.Unmarshal(data, &g); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGameListUnmarshalBinary(b *testing.B) {
+	data, _ := benchGameList.MarshalBinary()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var g GameList
+		if err := g.UnmarshalBinary(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCharList2MarshalReflect(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := bnet.Marshal(&benchCharList2); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCharList2AppendBinary(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 128)
+	for i := 0; i < b.N; i++ {
+		var err error
+		if buf, err = benchCharList2.AppendBinary(buf[:0]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCharList2UnmarshalReflect(b *testing.B) {
+	data, _ := benchCharList2.MarshalBinary()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var c CharList2
+		if err := bnet.Unmarshal(data, &c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCharList2UnmarshalBinary(b *testing.B) {
+	data, _ := benchCharList2.MarshalBinary()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var c CharList2
+		if err := c.UnmarshalBinary(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGameInfoMarshalReflect(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := bnet.Marshal(&benchGameInfo); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGameInfoAppendBinary(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 128)
+	for i := 0; i < b.N; i++ {
+		var err error
+		if buf, err = benchGameInfo.AppendBinary(buf[:0]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGameInfoUnmarshalBinary(b *testing.B) {
+	data, _ := benchGameInfo.MarshalBinary()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var g GameInfo
+		if err := g.UnmarshalBinary(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,6 +1,41 @@
+//go:generate $GOBIN/stringer -type=CharCreateResult
 package server
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+// CharCreateResult is the result of a MCP_CHARCREATE request
+type CharCreateResult uint32
+
+const (
+	CharCreateSuccess       CharCreateResult = 0x00
+	CharCreateAlreadyExists CharCreateResult = 0x14
+	CharCreateInvalidName   CharCreateResult = 0x15
+)
+
 // CharCreateResponse is the structure of a MCP_CHARCREATE response
 type CharCreate struct {
-	Result uint32
+	Result CharCreateResult
+}
+
+// ID returns the message ID of a MCP_CHARCREATE response
+func (CharCreate) ID() mcp.MessageID {
+	return mcp.McpCharCreate
+}
+
+// Direction returns the direction of a MCP_CHARCREATE response
+func (CharCreate) Direction() mcp.Direction {
+	return mcp.ServerToClient
+}
+
+// Err returns nil on success and the error describing r otherwise.
+func (r CharCreateResult) Err() error {
+	switch r {
+	case CharCreateSuccess:
+		return nil
+	case CharCreateAlreadyExists:
+		return ErrCharacterExists
+	case CharCreateInvalidName:
+		return ErrInvalidCharacterName
+	}
+	return &UnknownResultError{MessageID: mcp.McpCharCreate, Result: uint32(r)}
 }
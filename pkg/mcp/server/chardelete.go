@@ -1,6 +1,38 @@
+//go:generate $GOBIN/stringer -type=CharDeleteResult
 package server
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+// CharDeleteResult is the result of a MCP_CHARDELETE request
+type CharDeleteResult uint32
+
+const (
+	CharDeleteSuccess  CharDeleteResult = 0x00
+	CharDeleteNotFound CharDeleteResult = 0x49
+)
+
 // CharDeleteResponse is the structure of a MCP_CHARDELETE response
 type CharDelete struct {
-	Result uint32
+	Result CharDeleteResult
+}
+
+// ID returns the message ID of a MCP_CHARDELETE response
+func (CharDelete) ID() mcp.MessageID {
+	return mcp.McpCharDelete
+}
+
+// Direction returns the direction of a MCP_CHARDELETE response
+func (CharDelete) Direction() mcp.Direction {
+	return mcp.ServerToClient
+}
+
+// Err returns nil on success and the error describing r otherwise.
+func (r CharDeleteResult) Err() error {
+	switch r {
+	case CharDeleteSuccess:
+		return nil
+	case CharDeleteNotFound:
+		return ErrCharacterNotFound
+	}
+	return &UnknownResultError{MessageID: mcp.McpCharDelete, Result: uint32(r)}
 }
@@ -1,11 +1,21 @@
 package server
 
+import (
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/statstring"
+)
+
 // CharListResponseCharacter is the character structure of a MCP_CHARLIST response
 type CharListCharacter struct {
 	Name       string
 	Statstring string
 }
 
+// ParseStatstring decodes the statstring of c.
+func (c CharListCharacter) ParseStatstring() (*statstring.Statstring, error) {
+	return statstring.Parse(c.Statstring)
+}
+
 // CharListResponse is the structure of a MCP_CHARLIST response
 type CharList struct {
 	RequestCount  uint16
@@ -14,3 +24,26 @@ type CharList struct {
 
 	Characters []CharListCharacter `bnet:"len-CLReturned"`
 }
+
+// ID returns the message ID of a MCP_CHARLIST response
+func (CharList) ID() mcp.MessageID {
+	return mcp.McpCharList
+}
+
+// Direction returns the direction of a MCP_CHARLIST response
+func (CharList) Direction() mcp.Direction {
+	return mcp.ServerToClient
+}
+
+// Validate returns an error if c violates the constraints of a MCP_CHARLIST response.
+func (c CharList) Validate() error {
+	if int(c.ReturnedCount) != len(c.Characters) {
+		return &mcp.ValidationError{MessageID: c.ID(), Field: "ReturnedCount", Err: ErrCountMismatch}
+	}
+	for _, char := range c.Characters {
+		if err := mcp.ValidateCharacterName(char.Name); err != nil {
+			return &mcp.ValidationError{MessageID: c.ID(), Field: "Characters.Name", Err: err}
+		}
+	}
+	return nil
+}
@@ -1,5 +1,10 @@
 package server
 
+import (
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/statstring"
+)
+
 // CharList2ResponseCharacter is the character structure of a MCP_CHARLIST2 response
 type CharList2Character struct {
 	ExpirationDate uint32 // Unix time
@@ -7,6 +12,11 @@ type CharList2Character struct {
 	Statstring     string
 }
 
+// ParseStatstring decodes the statstring of c.
+func (c CharList2Character) ParseStatstring() (*statstring.Statstring, error) {
+	return statstring.Parse(c.Statstring)
+}
+
 // CharList2Response is the structure of a MCP_CHARLIST2 response
 type CharList2 struct {
 	RequestCount  uint16
@@ -15,3 +25,26 @@ type CharList2 struct {
 
 	Characters []CharList2Character `bnet:"len-CL2Returned"`
 }
+
+// ID returns the message ID of a MCP_CHARLIST2 response
+func (CharList2) ID() mcp.MessageID {
+	return mcp.McpCharList2
+}
+
+// Direction returns the direction of a MCP_CHARLIST2 response
+func (CharList2) Direction() mcp.Direction {
+	return mcp.ServerToClient
+}
+
+// Validate returns an error if c violates the constraints of a MCP_CHARLIST2 response.
+func (c CharList2) Validate() error {
+	if int(c.ReturnedCount) != len(c.Characters) {
+		return &mcp.ValidationError{MessageID: c.ID(), Field: "ReturnedCount", Err: ErrCountMismatch}
+	}
+	for _, char := range c.Characters {
+		if err := mcp.ValidateCharacterName(char.Name); err != nil {
+			return &mcp.ValidationError{MessageID: c.ID(), Field: "Characters.Name", Err: err}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+func TestCharListValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		returned uint16
+		names    []string
+		field    string
+		err      error
+	}{
+		{"empty", 0, nil, "", nil},
+		{"valid", 2, []string{"Conan", "Xe-na"}, "", nil},
+		{"short", 2, []string{"Conan"}, "ReturnedCount", ErrCountMismatch},
+		{"long", 1, []string{"Conan", "Xena"}, "ReturnedCount", ErrCountMismatch},
+		{"name length", 1, []string{"C"}, "Characters.Name", mcp.ErrCharacterNameLength},
+		{"name characters", 1, []string{"Conan2"}, "Characters.Name", mcp.ErrCharacterNameCharacters},
+	} {
+		c := CharList{ReturnedCount: tt.returned}
+		c2 := CharList2{ReturnedCount: tt.returned}
+		for _, name := range tt.names {
+			c.Characters = append(c.Characters, CharListCharacter{Name: name})
+			c2.Characters = append(c2.Characters, CharList2Character{Name: name})
+		}
+		wantValidationError(t, "CharList "+tt.name, c.Validate(), tt.field, tt.err)
+		wantValidationError(t, "CharList2 "+tt.name, c2.Validate(), tt.field, tt.err)
+	}
+}
@@ -1,6 +1,44 @@
+//go:generate $GOBIN/stringer -type=CharLogonResult
 package server
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+// CharLogonResult is the result of a MCP_CHARLOGON request
+type CharLogonResult uint32
+
+const (
+	CharLogonSuccess  CharLogonResult = 0x00
+	CharLogonNotFound CharLogonResult = 0x46
+	CharLogonFailed   CharLogonResult = 0x7a
+	CharLogonExpired  CharLogonResult = 0x7b
+)
+
 // CharLogonResponse is structure of a MCP_CHARLOGON response
 type CharLogon struct {
-	Result uint32
+	Result CharLogonResult
+}
+
+// ID returns the message ID of a MCP_CHARLOGON response
+func (CharLogon) ID() mcp.MessageID {
+	return mcp.McpCharLogon
+}
+
+// Direction returns the direction of a MCP_CHARLOGON response
+func (CharLogon) Direction() mcp.Direction {
+	return mcp.ServerToClient
+}
+
+// Err returns nil on success and the error describing r otherwise.
+func (r CharLogonResult) Err() error {
+	switch r {
+	case CharLogonSuccess:
+		return nil
+	case CharLogonNotFound:
+		return ErrCharacterNotFound
+	case CharLogonFailed:
+		return ErrCharacterLogonFailed
+	case CharLogonExpired:
+		return ErrCharacterExpired
+	}
+	return &UnknownResultError{MessageID: mcp.McpCharLogon, Result: uint32(r)}
 }
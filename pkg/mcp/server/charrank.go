@@ -0,0 +1,36 @@
+//go:generate $GOBIN/stringer -type=CharRankResult
+package server
+
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+// CharRankResult is the result of a MCP_CHARRANK request
+type CharRankResult uint32
+
+const (
+	CharRankSuccess CharRankResult = 0x00
+)
+
+// CharRankResponse is the structure of a MCP_CHARRANK response
+type CharRank struct {
+	Result CharRankResult
+	Rank   uint32
+}
+
+// ID returns the message ID of a MCP_CHARRANK response
+func (CharRank) ID() mcp.MessageID {
+	return mcp.McpCharRank
+}
+
+// Direction returns the direction of a MCP_CHARRANK response
+func (CharRank) Direction() mcp.Direction {
+	return mcp.ServerToClient
+}
+
+// Err returns nil on success and the error describing r otherwise.
+func (r CharRankResult) Err() error {
+	switch r {
+	case CharRankSuccess:
+		return nil
+	}
+	return &UnknownResultError{MessageID: mcp.McpCharRank, Result: uint32(r)}
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+func TestCharRankRoundTrip(t *testing.T) {
+	msg := &CharRank{Result: CharRankSuccess, Rank: 17}
+	want := []byte{0x0b, 0x00, 0x16, 0x00, 0x00, 0x00, 0x00, 0x11, 0x00, 0x00, 0x00}
+
+	got, err := mcp.MarshalMessage(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got % x, want % x", got, want)
+	}
+
+	decoded, err := mcp.Decode(mcp.ServerToClient, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, msg) {
+		t.Errorf("decoded %#v, want %#v", decoded, msg)
+	}
+}
+
+func TestCharRankResultErr(t *testing.T) {
+	if err := CharRankSuccess.Err(); err != nil {
+		t.Errorf("CharRankSuccess.Err() = %v", err)
+	}
+	err := CharRankResult(0x46).Err()
+	if e, ok := err.(*UnknownResultError); !ok || e.MessageID != mcp.McpCharRank || e.Result != 0x46 {
+		t.Errorf("got %#v, want *UnknownResultError", err)
+	}
+}
+
+func TestCharUpgradeRoundTrip(t *testing.T) {
+	msg := &CharUpgrade{Result: CharUpgradeAlreadyExpansion}
+	want := []byte{0x07, 0x00, 0x18, 0x7c, 0x00, 0x00, 0x00}
+
+	got, err := mcp.MarshalMessage(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got % x, want % x", got, want)
+	}
+
+	decoded, err := mcp.Decode(mcp.ServerToClient, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, msg) {
+		t.Errorf("decoded %#v, want %#v", decoded, msg)
+	}
+}
+
+func TestCharUpgradeResultErr(t *testing.T) {
+	for r, want := range map[CharUpgradeResult]error{
+		CharUpgradeSuccess:          nil,
+		CharUpgradeNotFound:         ErrCharacterNotFound,
+		CharUpgradeFailed:           ErrCharacterUpgradeFailed,
+		CharUpgradeExpired:          ErrCharacterExpired,
+		CharUpgradeAlreadyExpansion: ErrAlreadyExpansion,
+	} {
+		if err := r.Err(); err != want {
+			t.Errorf("%s.Err() = %v, want %v", r, err, want)
+		}
+	}
+	if _, ok := CharUpgradeResult(0x01).Err().(*UnknownResultError); !ok {
+		t.Error("unknown result did not return *UnknownResultError")
+	}
+}
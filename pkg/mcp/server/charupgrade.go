@@ -0,0 +1,47 @@
+//go:generate $GOBIN/stringer -type=CharUpgradeResult
+package server
+
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+// CharUpgradeResult is the result of a MCP_CHARUPGRADE request
+type CharUpgradeResult uint32
+
+const (
+	CharUpgradeSuccess          CharUpgradeResult = 0x00
+	CharUpgradeNotFound         CharUpgradeResult = 0x46
+	CharUpgradeFailed           CharUpgradeResult = 0x7a
+	CharUpgradeExpired          CharUpgradeResult = 0x7b
+	CharUpgradeAlreadyExpansion CharUpgradeResult = 0x7c
+)
+
+// CharUpgradeResponse is the structure of a MCP_CHARUPGRADE response
+type CharUpgrade struct {
+	Result CharUpgradeResult
+}
+
+// ID returns the message ID of a MCP_CHARUPGRADE response
+func (CharUpgrade) ID() mcp.MessageID {
+	return mcp.McpCharUpgrade
+}
+
+// Direction returns the direction of a MCP_CHARUPGRADE response
+func (CharUpgrade) Direction() mcp.Direction {
+	return mcp.ServerToClient
+}
+
+// Err returns nil on success and the error describing r otherwise.
+func (r CharUpgradeResult) Err() error {
+	switch r {
+	case CharUpgradeSuccess:
+		return nil
+	case CharUpgradeNotFound:
+		return ErrCharacterNotFound
+	case CharUpgradeFailed:
+		return ErrCharacterUpgradeFailed
+	case CharUpgradeExpired:
+		return ErrCharacterExpired
+	case CharUpgradeAlreadyExpansion:
+		return ErrAlreadyExpansion
+	}
+	return &UnknownResultError{MessageID: mcp.McpCharUpgrade, Result: uint32(r)}
+}
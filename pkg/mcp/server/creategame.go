@@ -1,9 +1,50 @@
+//go:generate $GOBIN/stringer -type=CreateGameResult
 package server
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+// CreateGameResult is the result of a MCP_CREATEGAME request
+type CreateGameResult uint32
+
+const (
+	CreateGameSuccess       CreateGameResult = 0x00
+	CreateGameInvalidName   CreateGameResult = 0x1e
+	CreateGameAlreadyExists CreateGameResult = 0x1f
+	CreateGameServersDown   CreateGameResult = 0x20
+	CreateGameDeadHardcore  CreateGameResult = 0x6e
+)
+
 // CreateGameResponse is structure of a MCP_CREATEGAME response
 type CreateGame struct {
 	RequestID uint16
 	GameToken uint16
 	Unknown   uint16
-	Result    uint32
+	Result    CreateGameResult
+}
+
+// ID returns the message ID of a MCP_CREATEGAME response
+func (CreateGame) ID() mcp.MessageID {
+	return mcp.McpCreateGame
+}
+
+// Direction returns the direction of a MCP_CREATEGAME response
+func (CreateGame) Direction() mcp.Direction {
+	return mcp.ServerToClient
+}
+
+// Err returns nil on success and the error describing r otherwise.
+func (r CreateGameResult) Err() error {
+	switch r {
+	case CreateGameSuccess:
+		return nil
+	case CreateGameInvalidName:
+		return ErrInvalidGameName
+	case CreateGameAlreadyExists:
+		return ErrGameExists
+	case CreateGameServersDown:
+		return ErrGameServersDown
+	case CreateGameDeadHardcore:
+		return ErrDeadHardcore
+	}
+	return &UnknownResultError{MessageID: mcp.McpCreateGame, Result: uint32(r)}
 }
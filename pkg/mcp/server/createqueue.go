@@ -1,6 +1,18 @@
 package server
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
 // CreateQueueResponse is structure of a MCP_CREATEQUEUE response
 type CreateQueue struct {
 	Position uint32
 }
+
+// ID returns the message ID of a MCP_CREATEQUEUE response
+func (CreateQueue) ID() mcp.MessageID {
+	return mcp.McpCreateQueue
+}
+
+// Direction returns the direction of a MCP_CREATEQUEUE response
+func (CreateQueue) Direction() mcp.Direction {
+	return mcp.ServerToClient
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+// Errors returned by the Err method of response result codes.
+var (
+	ErrNoBattleNetConnection  = errors.New("mcp: realm unavailable, no battle.net connection detected")
+	ErrKeyBanned              = errors.New("mcp: CD key banned from realm play")
+	ErrTemporaryBan           = errors.New("mcp: connection temporarily restricted from realm")
+	ErrCharacterExists        = errors.New("mcp: character already exists")
+	ErrInvalidCharacterName   = errors.New("mcp: invalid character name")
+	ErrCharacterNotFound      = errors.New("mcp: character not found")
+	ErrCharacterLogonFailed   = errors.New("mcp: character logon failed")
+	ErrCharacterExpired       = errors.New("mcp: character expired")
+	ErrCharacterUpgradeFailed = errors.New("mcp: character upgrade failed")
+	ErrAlreadyExpansion       = errors.New("mcp: character is already an expansion character")
+	ErrInvalidGameName        = errors.New("mcp: invalid game name")
+	ErrGameExists             = errors.New("mcp: game already exists")
+	ErrGameServersDown        = errors.New("mcp: game servers are down")
+	ErrDeadHardcore           = errors.New("mcp: dead hardcore character")
+	ErrPasswordIncorrect      = errors.New("mcp: game password incorrect")
+	ErrGameNotFound           = errors.New("mcp: game does not exist")
+	ErrGameFull               = errors.New("mcp: game is full")
+	ErrLevelRequirement       = errors.New("mcp: level requirements not met")
+	ErrHardcoreOnly           = errors.New("mcp: non-hardcore character cannot join hardcore game")
+	ErrNightmareLocked        = errors.New("mcp: nightmare difficulty not unlocked")
+	ErrHellLocked             = errors.New("mcp: hell difficulty not unlocked")
+	ErrExpansionOnly          = errors.New("mcp: classic character cannot join expansion game")
+	ErrClassicOnly            = errors.New("mcp: expansion character cannot join classic game")
+	ErrLadderOnly             = errors.New("mcp: non-ladder character cannot join ladder game")
+)
+
+// Errors returned when validating responses.
+var (
+	ErrCountMismatch  = errors.New("mcp: count does not match data")
+	ErrRosterMismatch = errors.New("mcp: game roster does not match character count")
+	ErrInvalidPlayer  = errors.New("mcp: invalid player")
+)
+
+// An UnknownResultError occurs when a response carries a result code
+// with no known meaning.
+type UnknownResultError struct {
+	MessageID mcp.MessageID
+	Result    uint32
+}
+
+func (e *UnknownResultError) Error() string {
+	return fmt.Sprintf("mcp: unknown %s result 0x%02x", e.MessageID, e.Result)
+}
+
+// A LadderChunkError occurs when the chunks of a MCP_REQUESTLADDERDATA
+// response cannot be reassembled into a ladder.
+type LadderChunkError struct {
+	Offset int
+	Reason string
+}
+
+func (e *LadderChunkError) Error() string {
+	return fmt.Sprintf("mcp: ladder data at offset %d: %s", e.Offset, e.Reason)
+}
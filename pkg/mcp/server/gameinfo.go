@@ -1,5 +1,27 @@
 package server
 
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+
+	"github.com/samlitowitz/bnet-encoding/pkg/encoding/bnet"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+const (
+	MaxGamePlayers = 16
+
+	gameInfoFixedSize = 46 // bytes preceding Description
+)
+
+// Player is a character in a game
+type Player struct {
+	Name  string
+	Class mcp.CharacterClass
+	Level uint8
+}
+
 // GameInfoResponse is structure of a MCP_GAMEINFO response
 type GameInfo struct {
 	RequestID                  uint16
@@ -12,5 +34,130 @@ type GameInfo struct {
 	CharacterClasses           [16]uint8
 	CharacterLevels            [16]uint8
 	Description                string
-	CharacterNames             string
+	CharacterNames             string // Null separated
+}
+
+// UnmarshalBNet decodes a MCP_GAMEINFO response. Unlike the reflective
+// decoder it keeps every character name rather than only the first.
+func (g *GameInfo) UnmarshalBNet(data []byte) error {
+	_, err := g.DecodeBinary(data)
+	return err
+}
+
+// DecodeBinary decodes a MCP_GAMEINFO response from the start of data and
+// returns the number of bytes consumed, which ends after CharacterCount
+// names. A game without characters is sent with a single empty name,
+// which is consumed if present.
+func (g *GameInfo) DecodeBinary(data []byte) (int, error) {
+	if len(data) < gameInfoFixedSize {
+		return 0, &bnet.IndexOutOfRangeError{N: gameInfoFixedSize, Offset: 0, Struct: "GameInfo"}
+	}
+
+	off := gameInfoFixedSize
+	end := bytes.IndexByte(data[off:], 0x00)
+	if end < 0 {
+		return 0, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "GameInfo", Field: "Description"}
+	}
+	description := string(data[off : off+end])
+	off += end + 1
+
+	names := off
+	for i := 0; i < int(data[13]); i++ {
+		end := bytes.IndexByte(data[off:], 0x00)
+		if end < 0 {
+			return 0, &bnet.IndexOutOfRangeError{N: int64(len(data) - off + 1), Offset: int64(off), Struct: "GameInfo", Field: "CharacterNames"}
+		}
+		off += end + 1
+	}
+	var characterNames string
+	if off > names {
+		characterNames = string(data[names : off-1])
+	} else if off < len(data) && data[off] == 0x00 {
+		off++
+	}
+
+	*g = GameInfo{
+		RequestID:                  binary.LittleEndian.Uint16(data[0:]),
+		Status:                     binary.LittleEndian.Uint32(data[2:]),
+		Uptime:                     binary.LittleEndian.Uint32(data[6:]),
+		LevelRestrictionLevel:      data[10],
+		LevelRestrictionDifference: data[11],
+		MaxPlayers:                 data[12],
+		CharacterCount:             data[13],
+		Description:                description,
+		CharacterNames:             characterNames,
+	}
+	copy(g.CharacterClasses[:], data[14:30])
+	copy(g.CharacterLevels[:], data[30:46])
+	return off, nil
+}
+
+// Players returns the characters in the game. It returns an error if the
+// number of names does not match CharacterCount.
+func (g GameInfo) Players() ([]Player, error) {
+	count := int(g.CharacterCount)
+	if count > MaxGamePlayers {
+		return nil, ErrRosterMismatch
+	}
+
+	var names []string
+	if g.CharacterNames != "" {
+		names = strings.Split(g.CharacterNames, "\x00")
+	}
+	if len(names) != count {
+		return nil, ErrRosterMismatch
+	}
+
+	players := make([]Player, count)
+	for i := range players {
+		players[i] = Player{
+			Name:  names[i],
+			Class: mcp.CharacterClass(g.CharacterClasses[i]),
+			Level: g.CharacterLevels[i],
+		}
+	}
+	return players, nil
+}
+
+// SetPlayers packs players into CharacterCount, CharacterClasses,
+// CharacterLevels and CharacterNames.
+func (g *GameInfo) SetPlayers(players []Player) error {
+	if len(players) > MaxGamePlayers {
+		return ErrRosterMismatch
+	}
+
+	var classes, levels [16]uint8
+	names := make([]string, len(players))
+	for i, p := range players {
+		if p.Name == "" || strings.IndexByte(p.Name, 0x00) >= 0 || p.Class > 0xff {
+			return ErrInvalidPlayer
+		}
+		classes[i] = uint8(p.Class)
+		levels[i] = p.Level
+		names[i] = p.Name
+	}
+
+	g.CharacterCount = uint8(len(players))
+	g.CharacterClasses = classes
+	g.CharacterLevels = levels
+	g.CharacterNames = strings.Join(names, "\x00")
+	return nil
+}
+
+// ID returns the message ID of a MCP_GAMEINFO response
+func (GameInfo) ID() mcp.MessageID {
+	return mcp.McpGameInfo
+}
+
+// Direction returns the direction of a MCP_GAMEINFO response
+func (GameInfo) Direction() mcp.Direction {
+	return mcp.ServerToClient
+}
+
+// Validate returns an error if g violates the constraints of a MCP_GAMEINFO response.
+func (g GameInfo) Validate() error {
+	if _, err := g.Players(); err != nil {
+		return &mcp.ValidationError{MessageID: g.ID(), Field: "CharacterNames", Err: err}
+	}
+	return nil
 }
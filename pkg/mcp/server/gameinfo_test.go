@@ -0,0 +1,65 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGameInfoDecodeBinary(t *testing.T) {
+	for _, players := range [][]Player{
+		nil,
+		{{Name: "Conan", Class: 4, Level: 42}},
+		{{Name: "Conan", Class: 4, Level: 42}, {Name: "Xena", Class: 0, Level: 1}},
+	} {
+		sent := GameInfo{RequestID: 0x0005, MaxPlayers: 8, Description: "fast runs"}
+		if err := sent.SetPlayers(players); err != nil {
+			t.Fatal(err)
+		}
+		data, err := sent.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Bytes following the names are left unconsumed.
+		var got GameInfo
+		n, err := got.DecodeBinary(append(data, 0xaa, 0xbb))
+		if err != nil {
+			t.Fatalf("%d players: %s", len(players), err)
+		}
+		if n != len(data) {
+			t.Errorf("%d players: consumed %d bytes, want %d", len(players), n, len(data))
+		}
+		if got != sent {
+			t.Errorf("%d players: got %#v, want %#v", len(players), got, sent)
+		}
+	}
+}
+
+func TestGameInfoDecodeBinaryMissingName(t *testing.T) {
+	sent := GameInfo{Description: "fast runs", CharacterCount: 2, CharacterNames: "Conan"}
+	data, err := sent.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got GameInfo
+	if _, err := got.DecodeBinary(data); err == nil {
+		t.Errorf("decoded %q as 2 names", bytes.TrimSuffix(data[gameInfoFixedSize:], []byte{0x00}))
+	}
+}
+
+func TestGameInfoValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		msg   GameInfo
+		field string
+	}{
+		{"empty", GameInfo{}, ""},
+		{"valid", GameInfo{CharacterCount: 2, CharacterNames: "Conan\x00Xena"}, ""},
+		{"missing name", GameInfo{CharacterCount: 2, CharacterNames: "Conan"}, "CharacterNames"},
+		{"extra name", GameInfo{CharacterCount: 1, CharacterNames: "Conan\x00Xena"}, "CharacterNames"},
+		{"too many players", GameInfo{CharacterCount: MaxGamePlayers + 1}, "CharacterNames"},
+	} {
+		wantValidationError(t, tt.name, tt.msg.Validate(), tt.field, ErrRosterMismatch)
+	}
+}
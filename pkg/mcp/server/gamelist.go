@@ -1,5 +1,7 @@
 package server
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
 // GameListResponse is structure of a MCP_GAMELIST response
 type GameList struct {
 	RequestID   uint16
@@ -9,3 +11,13 @@ type GameList struct {
 	Name        string
 	Description string
 }
+
+// ID returns the message ID of a MCP_GAMELIST response
+func (GameList) ID() mcp.MessageID {
+	return mcp.McpGameList
+}
+
+// Direction returns the direction of a MCP_GAMELIST response
+func (GameList) Direction() mcp.Direction {
+	return mcp.ServerToClient
+}
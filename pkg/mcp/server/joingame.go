@@ -1,5 +1,26 @@
+//go:generate $GOBIN/stringer -type=JoinGameResult
 package server
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+// JoinGameResult is the result of a MCP_JOINGAME request
+type JoinGameResult uint32
+
+const (
+	JoinGameSuccess           JoinGameResult = 0x00
+	JoinGamePasswordIncorrect JoinGameResult = 0x29
+	JoinGameNotFound          JoinGameResult = 0x2a
+	JoinGameFull              JoinGameResult = 0x2b
+	JoinGameLevelRequirement  JoinGameResult = 0x2c
+	JoinGameDeadHardcore      JoinGameResult = 0x6e
+	JoinGameHardcoreOnly      JoinGameResult = 0x71
+	JoinGameNightmareLocked   JoinGameResult = 0x73
+	JoinGameHellLocked        JoinGameResult = 0x74
+	JoinGameExpansionOnly     JoinGameResult = 0x78
+	JoinGameClassicOnly       JoinGameResult = 0x79
+	JoinGameLadderOnly        JoinGameResult = 0x7d
+)
+
 // JoinGameResponse is structure of a MCP_JOINGAME response
 type JoinGame struct {
 	RequestID    uint16
@@ -7,5 +28,46 @@ type JoinGame struct {
 	Unknown      uint16
 	GameServerIP [4]uint8
 	GameHash     uint32
-	Result       uint32
+	Result       JoinGameResult
+}
+
+// ID returns the message ID of a MCP_JOINGAME response
+func (JoinGame) ID() mcp.MessageID {
+	return mcp.McpJoinGame
+}
+
+// Direction returns the direction of a MCP_JOINGAME response
+func (JoinGame) Direction() mcp.Direction {
+	return mcp.ServerToClient
+}
+
+// Err returns nil on success and the error describing r otherwise.
+func (r JoinGameResult) Err() error {
+	switch r {
+	case JoinGameSuccess:
+		return nil
+	case JoinGamePasswordIncorrect:
+		return ErrPasswordIncorrect
+	case JoinGameNotFound:
+		return ErrGameNotFound
+	case JoinGameFull:
+		return ErrGameFull
+	case JoinGameLevelRequirement:
+		return ErrLevelRequirement
+	case JoinGameDeadHardcore:
+		return ErrDeadHardcore
+	case JoinGameHardcoreOnly:
+		return ErrHardcoreOnly
+	case JoinGameNightmareLocked:
+		return ErrNightmareLocked
+	case JoinGameHellLocked:
+		return ErrHellLocked
+	case JoinGameExpansionOnly:
+		return ErrExpansionOnly
+	case JoinGameClassicOnly:
+		return ErrClassicOnly
+	case JoinGameLadderOnly:
+		return ErrLadderOnly
+	}
+	return &UnknownResultError{MessageID: mcp.McpJoinGame, Result: uint32(r)}
 }
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+// MOTDResponse is the structure of a MCP_MOTD response
+type MOTD struct {
+	Unknown uint8
+	Message string // Lines are separated by "\n"
+}
+
+// NewMOTD returns a MCP_MOTD response whose message is lines joined by
+// newlines.
+func NewMOTD(lines ...string) *MOTD {
+	return &MOTD{Message: strings.Join(lines, "\n")}
+}
+
+// Lines returns the lines of the message. Carriage returns preceding a
+// newline are dropped.
+func (m MOTD) Lines() []string {
+	lines := strings.Split(m.Message, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSuffix(lines[i], "\r")
+	}
+	return lines
+}
+
+// ID returns the message ID of a MCP_MOTD response
+func (MOTD) ID() mcp.MessageID {
+	return mcp.McpMOTD
+}
+
+// Direction returns the direction of a MCP_MOTD response
+func (MOTD) Direction() mcp.Direction {
+	return mcp.ServerToClient
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+func TestMOTDRoundTrip(t *testing.T) {
+	motd := NewMOTD("Welcome", "Ladder resets soon")
+	want := append([]byte{0x1f, 0x00, 0x12, 0x00}, "Welcome\nLadder resets soon\x00"...)
+
+	got, err := mcp.MarshalMessage(motd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got % x, want % x", got, want)
+	}
+
+	msg, err := mcp.Decode(mcp.ServerToClient, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(msg, motd) {
+		t.Errorf("decoded %#v, want %#v", msg, motd)
+	}
+}
+
+func TestMOTDLines(t *testing.T) {
+	m := MOTD{Message: "Welcome\r\nLadder resets soon"}
+	want := []string{"Welcome", "Ladder resets soon"}
+	if got := m.Lines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
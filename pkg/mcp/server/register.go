@@ -0,0 +1,23 @@
+//go:generate go run ../../../internal/cmd/binarygen -type=Startup,CharCreate,CreateGame,JoinGame,GameList,GameInfo,CharLogon,CharDelete,RequestLadderData,MOTD,CreateQueue,CharRank,CharList,CharListCharacter,CharUpgrade,CharList2,CharList2Character,LadderEntry
+//go:generate go run ../../../internal/cmd/enumgen -type=StartupResult,CharCreateResult,CreateGameResult,JoinGameResult,CharLogonResult,CharDeleteResult,CharRankResult,CharUpgradeResult
+package server
+
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+func init() {
+	mcp.Register(Startup{})
+	mcp.Register(CharCreate{})
+	mcp.Register(CreateGame{})
+	mcp.Register(JoinGame{})
+	mcp.Register(GameList{})
+	mcp.Register(GameInfo{})
+	mcp.Register(CharLogon{})
+	mcp.Register(CharDelete{})
+	mcp.Register(RequestLadderData{})
+	mcp.Register(MOTD{})
+	mcp.Register(CreateQueue{})
+	mcp.Register(CharRank{})
+	mcp.Register(CharList{})
+	mcp.Register(CharUpgrade{})
+	mcp.Register(CharList2{})
+}
@@ -0,0 +1,188 @@
+package server
+
+import (
+	"bytes"
+
+	"github.com/samlitowitz/bnet-encoding/pkg/encoding/bnet"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+const (
+	ladderHeaderSize = 8  // bytes
+	ladderEntrySize  = 28 // bytes
+)
+
+// RequestLadderDataResponse is the structure of a single MCP_REQUESTLADDERDATA
+// response. A ladder is split across several responses, each carrying the
+// next TotalSize - RemainingSize bytes of the ladder data.
+type RequestLadderData struct {
+	LadderType    uint8
+	TotalSize     uint16
+	ChunkSize     uint16 `bnet:"save-RLDChunk"`
+	RemainingSize uint16 // Size of the chunks following this one
+	FirstRank     uint16
+	Unknown       uint16
+
+	Data []uint8 `bnet:"len-RLDChunk"`
+}
+
+// ID returns the message ID of a MCP_REQUESTLADDERDATA response
+func (RequestLadderData) ID() mcp.MessageID {
+	return mcp.McpRequestLadderData
+}
+
+// Direction returns the direction of a MCP_REQUESTLADDERDATA response
+func (RequestLadderData) Direction() mcp.Direction {
+	return mcp.ServerToClient
+}
+
+// LadderEntry is the structure of a character in the ladder data
+type LadderEntry struct {
+	Experience uint64
+	Status     uint16 // Class and character flags
+	Level      uint8
+	Unknown    uint8
+	Name       [16]uint8 // Null padded
+}
+
+// CharacterName returns Name up to the first null byte.
+func (e LadderEntry) CharacterName() string {
+	if i := bytes.IndexByte(e.Name[:], 0x00); i >= 0 {
+		return string(e.Name[:i])
+	}
+	return string(e.Name[:])
+}
+
+// ladderHeader precedes the entries in the ladder data
+type ladderHeader struct {
+	Count   uint32
+	Unknown uint32 // Always 0x10
+}
+
+// LadderReassembler collects the chunks of a ladder sent across several
+// MCP_REQUESTLADDERDATA responses.
+type LadderReassembler struct {
+	started   bool
+	first     RequestLadderData
+	data      []byte
+	completed bool
+}
+
+// Add appends the data carried by chunk. Chunks must be added in the
+// order they were sent. Add reports whether the ladder is complete.
+func (r *LadderReassembler) Add(chunk *RequestLadderData) (bool, error) {
+	if r.completed {
+		return true, &LadderChunkError{Offset: len(r.data), Reason: "ladder already complete"}
+	}
+	if !r.started {
+		r.started = true
+		r.first = *chunk
+		r.first.Data = nil
+		r.data = make([]byte, 0, chunk.TotalSize)
+	}
+
+	if chunk.LadderType != r.first.LadderType || chunk.TotalSize != r.first.TotalSize {
+		return false, &LadderChunkError{Offset: len(r.data), Reason: "chunk belongs to a different ladder"}
+	}
+	if int(chunk.ChunkSize) != len(chunk.Data) {
+		return false, &LadderChunkError{Offset: len(r.data), Reason: "chunk size does not match data"}
+	}
+	if int(chunk.ChunkSize)+int(chunk.RemainingSize) > int(chunk.TotalSize) {
+		return false, &LadderChunkError{Offset: len(r.data), Reason: "chunk exceeds total size"}
+	}
+	offset := int(chunk.TotalSize) - int(chunk.RemainingSize) - int(chunk.ChunkSize)
+	if offset != len(r.data) {
+		return false, &LadderChunkError{Offset: len(r.data), Reason: "chunk out of order"}
+	}
+
+	r.data = append(r.data, chunk.Data...)
+	r.completed = chunk.RemainingSize == 0
+	return r.completed, nil
+}
+
+// LadderType returns the ladder type of the chunks added so far.
+func (r *LadderReassembler) LadderType() uint8 {
+	return r.first.LadderType
+}
+
+// FirstRank returns the rank of the first entry of the ladder.
+func (r *LadderReassembler) FirstRank() uint16 {
+	return r.first.FirstRank
+}
+
+// Entries returns the entries of a complete ladder.
+func (r *LadderReassembler) Entries() ([]LadderEntry, error) {
+	if !r.completed {
+		return nil, &LadderChunkError{Offset: len(r.data), Reason: "ladder incomplete"}
+	}
+	if len(r.data) < ladderHeaderSize {
+		return nil, &LadderChunkError{Offset: len(r.data), Reason: "ladder header truncated"}
+	}
+
+	var hdr ladderHeader
+	if err := bnet.Unmarshal(r.data[:ladderHeaderSize], &hdr); err != nil {
+		return nil, err
+	}
+	if uint64(hdr.Count)*ladderEntrySize != uint64(len(r.data)-ladderHeaderSize) {
+		return nil, &LadderChunkError{Offset: ladderHeaderSize, Reason: "entry count does not match data"}
+	}
+
+	entries := make([]LadderEntry, hdr.Count)
+	for i := range entries {
+		off := ladderHeaderSize + i*ladderEntrySize
+		if err := bnet.Unmarshal(r.data[off:off+ladderEntrySize], &entries[i]); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// SplitLadder returns the MCP_REQUESTLADDERDATA responses carrying entries,
+// the first of which is at rank firstRank. Each response carries at most
+// chunkSize bytes of ladder data.
+func SplitLadder(ladderType uint8, firstRank uint16, entries []LadderEntry, chunkSize int) ([]*RequestLadderData, error) {
+	if chunkSize <= 0 {
+		return nil, &LadderChunkError{Reason: "chunk size must be positive"}
+	}
+
+	data, err := bnet.Marshal(&ladderHeader{Count: uint32(len(entries)), Unknown: 0x10})
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := bnet.Marshal(entries)
+	if err != nil {
+		return nil, err
+	}
+	data = append(data, encoded...)
+	if len(data) > 0xffff {
+		return nil, &LadderChunkError{Offset: len(data), Reason: "ladder too large"}
+	}
+
+	var chunks []*RequestLadderData
+	for off := 0; off < len(data); off += chunkSize {
+		end := off + chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		chunks = append(chunks, &RequestLadderData{
+			LadderType:    ladderType,
+			TotalSize:     uint16(len(data)),
+			ChunkSize:     uint16(end - off),
+			RemainingSize: uint16(len(data) - end),
+			FirstRank:     firstRank,
+			Data:          data[off:end],
+		})
+	}
+	return chunks, nil
+}
+
+// Validate returns an error if r violates the constraints of a MCP_REQUESTLADDERDATA response.
+func (r RequestLadderData) Validate() error {
+	if int(r.ChunkSize) != len(r.Data) {
+		return &mcp.ValidationError{MessageID: r.ID(), Field: "ChunkSize", Err: ErrCountMismatch}
+	}
+	if int(r.ChunkSize)+int(r.RemainingSize) > int(r.TotalSize) {
+		return &mcp.ValidationError{MessageID: r.ID(), Field: "TotalSize", Err: ErrCountMismatch}
+	}
+	return nil
+}
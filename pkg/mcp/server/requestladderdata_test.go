@@ -0,0 +1,169 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+func ladderEntry(name string, level uint8, experience uint64) LadderEntry {
+	e := LadderEntry{Experience: experience, Status: 0x0004, Level: level}
+	copy(e.Name[:], name)
+	return e
+}
+
+var testLadder = []LadderEntry{
+	ladderEntry("Conan", 99, 3520485254),
+	ladderEntry("Xena", 97, 2745491321),
+	ladderEntry("Valeria", 95, 2292657871),
+}
+
+// splitTestLadder returns testLadder split into 3 chunks of at most 32
+// bytes.
+func splitTestLadder(t *testing.T) []*RequestLadderData {
+	t.Helper()
+	chunks, err := SplitLadder(0x13, 1, testLadder, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 3 {
+		t.Fatalf("got %d chunks, want 3", len(chunks))
+	}
+	return chunks
+}
+
+func wantChunkError(t *testing.T, err error, reason string) {
+	t.Helper()
+	e, ok := err.(*LadderChunkError)
+	if !ok {
+		t.Fatalf("got %v, want *LadderChunkError", err)
+	}
+	if e.Reason != reason {
+		t.Errorf("got reason %q, want %q", e.Reason, reason)
+	}
+}
+
+func TestSplitLadderRoundTrip(t *testing.T) {
+	for _, entries := range [][]LadderEntry{testLadder, {}} {
+		chunks, err := SplitLadder(0x13, 1, entries, 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var r LadderReassembler
+		for i, c := range chunks {
+			done, err := r.Add(c)
+			if err != nil {
+				t.Fatalf("chunk %d: %s", i, err)
+			}
+			if done != (i == len(chunks)-1) {
+				t.Errorf("chunk %d: done = %t", i, done)
+			}
+		}
+
+		got, err := r.Entries()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(entries) || len(entries) > 0 && !reflect.DeepEqual(got, entries) {
+			t.Errorf("got %v, want %v", got, entries)
+		}
+		if r.LadderType() != 0x13 || r.FirstRank() != 1 {
+			t.Errorf("ladder type %#x, first rank %d", r.LadderType(), r.FirstRank())
+		}
+	}
+}
+
+func TestLadderReassemblerOutOfOrder(t *testing.T) {
+	chunks := splitTestLadder(t)
+
+	var r LadderReassembler
+	if _, err := r.Add(chunks[0]); err != nil {
+		t.Fatal(err)
+	}
+	_, err := r.Add(chunks[2])
+	wantChunkError(t, err, "chunk out of order")
+
+	// The rejected chunk leaves the reassembler usable.
+	for _, c := range chunks[1:] {
+		if _, err := r.Add(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := r.Entries(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLadderReassemblerDuplicate(t *testing.T) {
+	chunks := splitTestLadder(t)
+
+	var r LadderReassembler
+	if _, err := r.Add(chunks[0]); err != nil {
+		t.Fatal(err)
+	}
+	_, err := r.Add(chunks[0])
+	wantChunkError(t, err, "chunk out of order")
+}
+
+func TestLadderReassemblerTotalSizeMismatch(t *testing.T) {
+	chunks := splitTestLadder(t)
+
+	var r LadderReassembler
+	if _, err := r.Add(chunks[0]); err != nil {
+		t.Fatal(err)
+	}
+	other := *chunks[1]
+	other.TotalSize++
+	_, err := r.Add(&other)
+	wantChunkError(t, err, "chunk belongs to a different ladder")
+}
+
+func TestLadderReassemblerOverflow(t *testing.T) {
+	chunks := splitTestLadder(t)
+
+	var r LadderReassembler
+	if _, err := r.Add(chunks[0]); err != nil {
+		t.Fatal(err)
+	}
+	// Claims more data remains than the total size leaves room for
+	over := *chunks[1]
+	over.RemainingSize = over.TotalSize
+	_, err := r.Add(&over)
+	wantChunkError(t, err, "chunk exceeds total size")
+
+	for _, c := range chunks[1:] {
+		if _, err := r.Add(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = r.Add(chunks[2])
+	wantChunkError(t, err, "ladder already complete")
+}
+
+func TestLadderReassemblerIncomplete(t *testing.T) {
+	chunks := splitTestLadder(t)
+
+	var r LadderReassembler
+	_, err := r.Entries()
+	wantChunkError(t, err, "ladder incomplete")
+
+	if _, err := r.Add(chunks[0]); err != nil {
+		t.Fatal(err)
+	}
+	_, err = r.Entries()
+	wantChunkError(t, err, "ladder incomplete")
+}
+
+func TestRequestLadderDataValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		msg   RequestLadderData
+		field string
+	}{
+		{"valid", RequestLadderData{TotalSize: 5, ChunkSize: 3, RemainingSize: 2, Data: []uint8{1, 2, 3}}, ""},
+		{"chunk size", RequestLadderData{TotalSize: 5, ChunkSize: 4, RemainingSize: 1, Data: []uint8{1, 2, 3}}, "ChunkSize"},
+		{"total size", RequestLadderData{TotalSize: 4, ChunkSize: 3, RemainingSize: 2, Data: []uint8{1, 2, 3}}, "TotalSize"},
+	} {
+		wantValidationError(t, tt.name, tt.msg.Validate(), tt.field, ErrCountMismatch)
+	}
+}
@@ -1,6 +1,50 @@
+//go:generate $GOBIN/stringer -type=StartupResult
 package server
 
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+// StartupResult is the result of a MCP_STARTUP request
+type StartupResult uint32
+
+const (
+	StartupSuccess               StartupResult = 0x00
+	StartupNoBattleNetConnection StartupResult = 0x02
+	// Also sent when no Battle.net connection is detected
+	StartupNoBattleNetConnection0A StartupResult = 0x0a
+	StartupNoBattleNetConnection0B StartupResult = 0x0b
+	StartupNoBattleNetConnection0C StartupResult = 0x0c
+	StartupNoBattleNetConnection0D StartupResult = 0x0d
+	StartupKeyBanned               StartupResult = 0x7e
+	StartupTemporaryBan            StartupResult = 0x7f
+)
+
 // StartupResponse is the structure of a MCP_STARTUP response
 type Startup struct {
-	Result uint32
+	Result StartupResult
+}
+
+// ID returns the message ID of a MCP_STARTUP response
+func (Startup) ID() mcp.MessageID {
+	return mcp.McpStartup
+}
+
+// Direction returns the direction of a MCP_STARTUP response
+func (Startup) Direction() mcp.Direction {
+	return mcp.ServerToClient
+}
+
+// Err returns nil on success and the error describing r otherwise.
+func (r StartupResult) Err() error {
+	switch r {
+	case StartupSuccess:
+		return nil
+	case StartupNoBattleNetConnection, StartupNoBattleNetConnection0A, StartupNoBattleNetConnection0B,
+		StartupNoBattleNetConnection0C, StartupNoBattleNetConnection0D:
+		return ErrNoBattleNetConnection
+	case StartupKeyBanned:
+		return ErrKeyBanned
+	case StartupTemporaryBan:
+		return ErrTemporaryBan
+	}
+	return &UnknownResultError{MessageID: mcp.McpStartup, Result: uint32(r)}
 }
@@ -0,0 +1,23 @@
+package server
+
+import "testing"
+
+func TestStartupResultErr(t *testing.T) {
+	for r, want := range map[StartupResult]error{
+		StartupSuccess:                 nil,
+		StartupNoBattleNetConnection:   ErrNoBattleNetConnection,
+		StartupNoBattleNetConnection0A: ErrNoBattleNetConnection,
+		StartupNoBattleNetConnection0B: ErrNoBattleNetConnection,
+		StartupNoBattleNetConnection0C: ErrNoBattleNetConnection,
+		StartupNoBattleNetConnection0D: ErrNoBattleNetConnection,
+		StartupKeyBanned:               ErrKeyBanned,
+		StartupTemporaryBan:            ErrTemporaryBan,
+	} {
+		if err := r.Err(); err != want {
+			t.Errorf("%s.Err() = %v, want %v", r, err, want)
+		}
+	}
+	if _, ok := StartupResult(0x0e).Err().(*UnknownResultError); !ok {
+		t.Error("unknown result did not return *UnknownResultError")
+	}
+}
@@ -0,0 +1,13 @@
+package statstring
+
+import "fmt"
+
+// A SyntaxError occurs when a statstring cannot be parsed or built.
+type SyntaxError struct {
+	Offset int
+	Reason string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("statstring: %s at offset %d", e.Reason, e.Offset)
+}
@@ -0,0 +1,186 @@
+// Package statstring implements the character statstrings carried by the
+// MCP_CHARLIST and MCP_CHARLIST2 responses.
+package statstring
+
+import "github.com/samlitowitz/bnet-mcp/pkg/mcp"
+
+const (
+	Length = 33 // bytes
+
+	header0 = 0x84
+	header1 = 0x80
+	padByte = 0xff
+	mask    = 0x80
+)
+
+const (
+	offHeader      = 0
+	offGraphics    = 2
+	offClass       = 13
+	offColors      = 14
+	offLevel       = 25
+	offFlags       = 26
+	offProgression = 27
+	offUnknown1    = 28
+	offLadder      = 30
+	offUnknown2    = 31
+)
+
+// Flags are the character flags of a statstring
+type Flags uint8
+
+const (
+	FlagHardcore  Flags = 0x04
+	FlagDead      Flags = 0x08
+	FlagExpansion Flags = 0x20
+	FlagLadder    Flags = 0x40
+)
+
+// Statstring is the decoded form of a character statstring. Graphics and
+// colors of 0 denote an empty equipment slot.
+type Statstring struct {
+	Graphics    [11]uint8
+	Class       mcp.CharacterClass
+	Colors      [11]uint8
+	Level       uint8
+	Flags       Flags
+	Progression uint8 // Acts completed across all difficulties
+	Unknown1    [2]uint8
+	Ladder      uint8 // Ladder season, 0 if not ladder
+	Unknown2    [2]uint8
+}
+
+// Parse decodes a statstring.
+func Parse(s string) (*Statstring, error) {
+	if len(s) != Length {
+		return nil, &SyntaxError{Offset: len(s), Reason: "invalid length"}
+	}
+	if s[offHeader] != header0 || s[offHeader+1] != header1 {
+		return nil, &SyntaxError{Offset: offHeader, Reason: "invalid header"}
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] == 0x00 {
+			return nil, &SyntaxError{Offset: i, Reason: "unexpected null byte"}
+		}
+	}
+
+	st := &Statstring{}
+	for i := range st.Graphics {
+		st.Graphics[i] = unpad(s[offGraphics+i])
+	}
+	st.Class = mcp.CharacterClass(s[offClass] - 1)
+	for i := range st.Colors {
+		st.Colors[i] = unpad(s[offColors+i])
+	}
+	st.Level = s[offLevel]
+	st.Flags = Flags(s[offFlags] &^ mask)
+	st.Progression = (s[offProgression] &^ mask) >> 1
+	st.Unknown1 = [2]uint8{s[offUnknown1], s[offUnknown1+1]}
+	st.Ladder = unpad(s[offLadder])
+	st.Unknown2 = [2]uint8{s[offUnknown2], s[offUnknown2+1]}
+
+	return st, nil
+}
+
+// Build encodes st as a statstring.
+func (st *Statstring) Build() (string, error) {
+	if st.Level == 0 {
+		return "", &SyntaxError{Offset: offLevel, Reason: "level must be at least 1"}
+	}
+	if st.Class >= 0xfe {
+		return "", &SyntaxError{Offset: offClass, Reason: "invalid class"}
+	}
+	if st.Progression > 0x3f>>1 {
+		return "", &SyntaxError{Offset: offProgression, Reason: "progression out of range"}
+	}
+
+	b := make([]byte, Length)
+	b[offHeader] = header0
+	b[offHeader+1] = header1
+	for i, g := range st.Graphics {
+		b[offGraphics+i] = pad(g)
+	}
+	b[offClass] = byte(st.Class) + 1
+	for i, c := range st.Colors {
+		b[offColors+i] = pad(c)
+	}
+	b[offLevel] = st.Level
+	b[offFlags] = byte(st.Flags) | mask
+	b[offProgression] = st.Progression<<1 | mask
+	b[offUnknown1] = unknown(st.Unknown1[0])
+	b[offUnknown1+1] = unknown(st.Unknown1[1])
+	b[offLadder] = pad(st.Ladder)
+	b[offUnknown2] = unknown(st.Unknown2[0])
+	b[offUnknown2+1] = unknown(st.Unknown2[1])
+
+	return string(b), nil
+}
+
+// Hardcore reports whether the character is hardcore.
+func (st *Statstring) Hardcore() bool {
+	return st.Flags&FlagHardcore != 0
+}
+
+// Dead reports whether the character is dead.
+func (st *Statstring) Dead() bool {
+	return st.Flags&FlagDead != 0
+}
+
+// Expansion reports whether the character is an expansion character.
+func (st *Statstring) Expansion() bool {
+	return st.Flags&FlagExpansion != 0
+}
+
+// IsLadder reports whether the character is a ladder character.
+func (st *Statstring) IsLadder() bool {
+	return st.Flags&FlagLadder != 0
+}
+
+// Difficulty returns the highest difficulty the character has reached,
+// 0 for normal through 2 for hell.
+func (st *Statstring) Difficulty() int {
+	d := int(st.Progression) / st.actsPerDifficulty()
+	if d > 2 {
+		d = 2
+	}
+	return d
+}
+
+// Act returns the act, from 1, the character has reached in Difficulty.
+func (st *Statstring) Act() int {
+	acts := st.actsPerDifficulty()
+	if int(st.Progression) >= 3*acts {
+		return acts
+	}
+	return int(st.Progression)%acts + 1
+}
+
+func (st *Statstring) actsPerDifficulty() int {
+	if st.Expansion() {
+		return 5
+	}
+	return 4
+}
+
+// pad replaces an empty value with the pad byte so the statstring never
+// contains a null byte.
+func pad(b uint8) byte {
+	if b == 0x00 {
+		return padByte
+	}
+	return b
+}
+
+func unpad(b byte) uint8 {
+	if b == padByte {
+		return 0x00
+	}
+	return b
+}
+
+func unknown(b uint8) byte {
+	if b == 0x00 {
+		return mask
+	}
+	return b
+}
@@ -0,0 +1,182 @@
+package statstring
+
+import (
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+// statstring assembles a statstring from its fields, leaving the equipment
+// slots empty except for the helm and chest.
+func statstring(class, level, flags, progression, ladder byte) string {
+	b := []byte{header0, header1}
+	b = append(b, 0x03, 0x02, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff) // Graphics
+	b = append(b, class)
+	b = append(b, 0x0a, 0x0b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff) // Colors
+	b = append(b, level, flags, progression)
+	b = append(b, 0x80, 0x80) // Unknown1
+	b = append(b, ladder)
+	b = append(b, 0x80, 0x80) // Unknown2
+	return string(b)
+}
+
+// statstringTests are synthetic. No statstrings captured from a realm
+// server are available yet, so these are assembled by statstring from the
+// documented layout and only show that Parse and Build agree with it.
+// Captured strings should be added here, with their source, as they
+// become available.
+var statstringTests = []struct {
+	name       string
+	s          string
+	class      mcp.CharacterClass
+	level      uint8
+	hardcore   bool
+	dead       bool
+	expansion  bool
+	ladder     bool
+	season     uint8
+	difficulty int
+	act        int
+}{
+	{
+		name:       "classic normal",
+		s:          statstring(0x01, 0x01, 0x80, 0x80, 0xff),
+		class:      mcp.ClassAmazon,
+		level:      1,
+		difficulty: 0,
+		act:        1,
+	},
+	{
+		name:       "classic hardcore dead",
+		s:          statstring(0x04, 0x2a, 0x8c, 0x8e, 0xff),
+		class:      mcp.ClassPaladin,
+		level:      42,
+		hardcore:   true,
+		dead:       true,
+		difficulty: 1,
+		act:        4,
+	},
+	{
+		name:       "expansion ladder",
+		s:          statstring(0x02, 0x5d, 0xe0, 0x94, 0x01),
+		class:      mcp.ClassSorceress,
+		level:      93,
+		expansion:  true,
+		ladder:     true,
+		season:     1,
+		difficulty: 2,
+		act:        1,
+	},
+	{
+		name:       "expansion hardcore completed",
+		s:          statstring(0x07, 0x63, 0xa4, 0x9e, 0xff),
+		class:      mcp.ClassAssassin,
+		level:      99,
+		hardcore:   true,
+		expansion:  true,
+		difficulty: 2,
+		act:        5,
+	},
+}
+
+func TestParse(t *testing.T) {
+	for _, tt := range statstringTests {
+		st, err := Parse(tt.s)
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if st.Class != tt.class || st.Level != tt.level || st.Ladder != tt.season {
+			t.Errorf("%s: class %s, level %d, ladder %d", tt.name, st.Class, st.Level, st.Ladder)
+		}
+		if st.Hardcore() != tt.hardcore || st.Dead() != tt.dead || st.Expansion() != tt.expansion || st.IsLadder() != tt.ladder {
+			t.Errorf("%s: flags %#02x", tt.name, st.Flags)
+		}
+		if st.Difficulty() != tt.difficulty || st.Act() != tt.act {
+			t.Errorf("%s: difficulty %d act %d, want difficulty %d act %d", tt.name, st.Difficulty(), st.Act(), tt.difficulty, tt.act)
+		}
+		if st.Graphics[0] != 0x03 || st.Graphics[2] != 0x00 || st.Colors[1] != 0x0b || st.Colors[2] != 0x00 {
+			t.Errorf("%s: graphics %v colors %v", tt.name, st.Graphics, st.Colors)
+		}
+	}
+}
+
+func TestBuildRoundTrip(t *testing.T) {
+	for _, tt := range statstringTests {
+		st, err := Parse(tt.s)
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		s, err := st.Build()
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if s != tt.s {
+			t.Errorf("%s: got % x, want % x", tt.name, s, tt.s)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	st := &Statstring{
+		Class:       mcp.ClassSorceress,
+		Level:       93,
+		Flags:       FlagExpansion | FlagLadder,
+		Progression: 10,
+		Ladder:      1,
+	}
+	st.Graphics[0], st.Graphics[1] = 0x03, 0x02
+	st.Colors[0], st.Colors[1] = 0x0a, 0x0b
+
+	s, err := st.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := statstring(0x02, 0x5d, 0xe0, 0x94, 0x01); s != want {
+		t.Errorf("got % x, want % x", s, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	valid := statstring(0x01, 0x01, 0x80, 0x80, 0xff)
+	for _, tt := range []struct {
+		name   string
+		s      string
+		offset int
+	}{
+		{"empty", "", 0},
+		{"short", valid[:Length-1], Length - 1},
+		{"long", valid + "\x80", Length + 1},
+		{"header", "\x85" + valid[1:], offHeader},
+		{"null byte", valid[:offLevel] + "\x00" + valid[offLevel+1:], offLevel},
+	} {
+		_, err := Parse(tt.s)
+		e, ok := err.(*SyntaxError)
+		if !ok {
+			t.Errorf("%s: got %v, want *SyntaxError", tt.name, err)
+			continue
+		}
+		if e.Offset != tt.offset {
+			t.Errorf("%s: offset %d, want %d", tt.name, e.Offset, tt.offset)
+		}
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		st     Statstring
+		offset int
+	}{
+		{"level", Statstring{Level: 0}, offLevel},
+		{"class", Statstring{Class: 0xfe, Level: 1}, offClass},
+		{"progression", Statstring{Level: 1, Progression: 0x20}, offProgression},
+	} {
+		_, err := tt.st.Build()
+		if e, ok := err.(*SyntaxError); !ok || e.Offset != tt.offset {
+			t.Errorf("%s: got %v, want *SyntaxError at offset %d", tt.name, err, tt.offset)
+		}
+	}
+}
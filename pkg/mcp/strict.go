@@ -0,0 +1,102 @@
+package mcp
+
+import "github.com/samlitowitz/bnet-encoding/pkg/encoding/bnet"
+
+// binaryDecoder is implemented by messages which report how many bytes of
+// a payload they consume
+type binaryDecoder interface {
+	DecodeBinary(data []byte) (int, error)
+}
+
+// DecodeStrict parses a wire frame sent in dir like Decode, but frame must
+// hold exactly one frame and the message must consume its whole payload.
+// Violations are reported as a *LengthMismatchError, *ShortFrameError or
+// *TrailingDataError.
+func DecodeStrict(dir Direction, frame []byte) (Message, error) {
+	if len(frame) < HeaderLength {
+		return nil, &InvalidLengthError{Length: len(frame)}
+	}
+
+	f := &Frame{Payload: frame[HeaderLength:]}
+	if err := f.Header.UnmarshalBinary(frame[:HeaderLength]); err != nil {
+		return nil, err
+	}
+	return DecodeFrameStrict(dir, f)
+}
+
+// DecodeFrameStrict returns a pointer to the registered message contained
+// in f under the rules of DecodeStrict.
+func DecodeFrameStrict(dir Direction, f *Frame) (Message, error) {
+	id := f.Header.MessageID
+	if f.Header.Length < HeaderLength {
+		return nil, &InvalidLengthError{Length: int(f.Header.Length)}
+	}
+	if actual := HeaderLength + len(f.Payload); int(f.Header.Length) != actual {
+		return nil, &LengthMismatchError{MessageID: id, Length: int(f.Header.Length), Actual: actual}
+	}
+
+	msg, n, err := decodeFrame(dir, f)
+	if err != nil {
+		switch e := err.(type) {
+		case *bnet.IndexOutOfRangeError:
+			return nil, &ShortFrameError{MessageID: id, Offset: HeaderLength + int(e.Offset), Err: err}
+		case *MalformedPayloadError:
+			// The reflective decoder panics rather than report where the
+			// payload ran out, so the frame is short at its end.
+			return nil, &ShortFrameError{MessageID: id, Offset: HeaderLength + len(f.Payload), Err: err}
+		}
+		return nil, err
+	}
+	if n < len(f.Payload) {
+		return nil, &TrailingDataError{MessageID: id, Offset: HeaderLength + n, Length: len(f.Payload) - n}
+	}
+	return msg, nil
+}
+
+// DecodeLenient parses a wire frame sent in dir like Decode and also
+// returns the payload bytes the message did not consume.
+func DecodeLenient(dir Direction, frame []byte) (Message, []byte, error) {
+	f, err := splitFrame(frame)
+	if err != nil {
+		return nil, nil, err
+	}
+	return DecodeFrameLenient(dir, f)
+}
+
+// DecodeFrameLenient returns a pointer to the registered message contained
+// in f and the payload bytes it did not consume.
+func DecodeFrameLenient(dir Direction, f *Frame) (Message, []byte, error) {
+	msg, n, err := decodeFrame(dir, f)
+	if err != nil {
+		return nil, nil, err
+	}
+	return msg, f.Payload[n:], nil
+}
+
+// decodeFrame decodes the message contained in f and returns the number
+// of payload bytes it consumed.
+func decodeFrame(dir Direction, f *Frame) (Message, int, error) {
+	msg, err := New(dir, f.Header.MessageID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if d, ok := msg.(binaryDecoder); ok {
+		n, err := d.DecodeBinary(f.Payload)
+		if err != nil {
+			return nil, 0, err
+		}
+		return msg, n, nil
+	}
+
+	// The reflective decoder does not report its offset, but the encoded
+	// size of what it decoded is the number of bytes it consumed.
+	if err := Unmarshal(f.Payload, msg); err != nil {
+		return nil, 0, err
+	}
+	b, err := Append(nil, f.Header.MessageID, msg)
+	if err != nil {
+		return nil, 0, err
+	}
+	return msg, len(b) - HeaderLength, nil
+}
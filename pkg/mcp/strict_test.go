@@ -0,0 +1,146 @@
+package mcp_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+)
+
+// withPayload returns a copy of frame with its payload replaced and its
+// header length updated to match.
+func withPayload(frame, payload []byte) []byte {
+	b := append(append([]byte{}, frame[:mcp.HeaderLength]...), payload...)
+	binary.LittleEndian.PutUint16(b, uint16(len(b)))
+	return b
+}
+
+func TestDecodeStrictCorpus(t *testing.T) {
+	for _, tc := range corpus {
+		frame := readVector(t, tc.dir, tc.file)
+
+		got, err := mcp.DecodeStrict(tc.dir, frame)
+		if err != nil {
+			t.Errorf("%s/%s: %s", tc.dir, tc.file, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s/%s: decoded\n%#v\nwant\n%#v", tc.dir, tc.file, got, tc.want)
+		}
+	}
+}
+
+func TestDecodeStrictTrailingData(t *testing.T) {
+	for _, tc := range corpus {
+		frame := readVector(t, tc.dir, tc.file)
+		frame = withPayload(frame, append(frame[mcp.HeaderLength:], 0xaa, 0xbb))
+
+		_, err := mcp.DecodeStrict(tc.dir, frame)
+		e, ok := err.(*mcp.TrailingDataError)
+		if !ok {
+			t.Errorf("%s/%s: got %v, want *TrailingDataError", tc.dir, tc.file, err)
+			continue
+		}
+		want := &mcp.TrailingDataError{MessageID: tc.want.ID(), Offset: len(frame) - 2, Length: 2}
+		if *e != *want {
+			t.Errorf("%s/%s: got %+v, want %+v", tc.dir, tc.file, e, want)
+		}
+
+		_, tail, err := mcp.DecodeLenient(tc.dir, frame)
+		if err != nil {
+			t.Errorf("%s/%s: lenient: %s", tc.dir, tc.file, err)
+		} else if !bytes.Equal(tail, []byte{0xaa, 0xbb}) {
+			t.Errorf("%s/%s: lenient tail % x", tc.dir, tc.file, tail)
+		}
+	}
+}
+
+func TestDecodeStrictShortFrame(t *testing.T) {
+	tests := []struct {
+		dir    mcp.Direction
+		file   string
+		cut    int
+		offset int
+	}{
+		{mcp.ClientToServer, "startup", 1, 3 + 64},
+		{mcp.ServerToClient, "joingame", 4, 3 + 14},
+		{mcp.ServerToClient, "gameinfo", 20, 3 + 46},
+		{mcp.ServerToClient, "gameinfo", 1, 3 + 46 + 10 + 6},
+		{mcp.ServerToClient, "charlist2", 1, 3 + 8 + 44 + 4 + 5},
+	}
+	for _, tc := range tests {
+		frame := readVector(t, tc.dir, tc.file)
+		frame = withPayload(frame, frame[mcp.HeaderLength:len(frame)-tc.cut])
+
+		_, err := mcp.DecodeStrict(tc.dir, frame)
+		e, ok := err.(*mcp.ShortFrameError)
+		if !ok {
+			t.Errorf("%s/%s: got %v, want *ShortFrameError", tc.dir, tc.file, err)
+			continue
+		}
+		if e.Offset != tc.offset {
+			t.Errorf("%s/%s: offset %d, want %d", tc.dir, tc.file, e.Offset, tc.offset)
+		}
+	}
+}
+
+// reflectiveMessage is decoded by the reflective decoder, which panics on
+// a string missing its terminator.
+type reflectiveMessage struct {
+	Unknown uint32
+	Text    string
+}
+
+func (reflectiveMessage) ID() mcp.MessageID        { return 0x7f }
+func (reflectiveMessage) Direction() mcp.Direction { return mcp.ServerToClient }
+
+func init() {
+	mcp.Register(reflectiveMessage{})
+}
+
+func TestDecodeStrictShortFrameReflective(t *testing.T) {
+	frame := []byte{0x0a, 0x00, 0x7f, 0x01, 0x00, 0x00, 0x00, 'a', 'b', 'c'}
+
+	_, err := mcp.DecodeStrict(mcp.ServerToClient, frame)
+	e, ok := err.(*mcp.ShortFrameError)
+	if !ok {
+		t.Fatalf("got %v, want *ShortFrameError", err)
+	}
+	if e.Offset != len(frame) {
+		t.Errorf("offset %d, want %d", e.Offset, len(frame))
+	}
+	if _, ok := e.Err.(*mcp.MalformedPayloadError); !ok {
+		t.Errorf("got %v, want *MalformedPayloadError", e.Err)
+	}
+}
+
+func TestDecodeStrictLengthMismatch(t *testing.T) {
+	frame := readVector(t, mcp.ClientToServer, "charlogon")
+
+	_, err := mcp.DecodeStrict(mcp.ClientToServer, append(frame, 0x00))
+	want := &mcp.LengthMismatchError{MessageID: mcp.McpCharLogon, Length: len(frame), Actual: len(frame) + 1}
+	if e, ok := err.(*mcp.LengthMismatchError); !ok || *e != *want {
+		t.Errorf("got %v, want %v", err, want)
+	}
+
+	_, err = mcp.DecodeStrict(mcp.ClientToServer, frame[:len(frame)-1])
+	want = &mcp.LengthMismatchError{MessageID: mcp.McpCharLogon, Length: len(frame), Actual: len(frame) - 1}
+	if e, ok := err.(*mcp.LengthMismatchError); !ok || *e != *want {
+		t.Errorf("got %v, want %v", err, want)
+	}
+}
+
+func TestReaderStrict(t *testing.T) {
+	frame := readVector(t, mcp.ClientToServer, "charlogon")
+	frame = withPayload(frame, append(frame[mcp.HeaderLength:], 0x00))
+
+	r := mcp.NewReader(bytes.NewReader(frame))
+	r.Strict = true
+	if _, err := r.ReadMessage(mcp.ClientToServer); err == nil {
+		t.Fatal("strict reader accepted trailing data")
+	} else if _, ok := err.(*mcp.TrailingDataError); !ok {
+		t.Fatalf("got %v, want *TrailingDataError", err)
+	}
+}
@@ -0,0 +1,54 @@
+package mcp
+
+import "errors"
+
+const (
+	MinCharacterNameLength = 2
+	MaxCharacterNameLength = 15
+)
+
+// Errors returned by ValidateCharacterName.
+var (
+	ErrCharacterNameLength     = errors.New("mcp: character name must be between 2 and 15 characters")
+	ErrCharacterNameCharacters = errors.New("mcp: character name may only contain letters and a single inner '-' or '_'")
+)
+
+// Validator is implemented by messages which can check their fields
+// against the constraints of the protocol.
+type Validator interface {
+	Validate() error
+}
+
+// Validate returns the result of msg.Validate if msg implements Validator
+// and nil otherwise.
+func Validate(msg Message) error {
+	if v, ok := msg.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// ValidateCharacterName returns an error if name cannot be used as a
+// character name. Names consist of letters and at most one '-' or '_'
+// which may not begin or end the name.
+func ValidateCharacterName(name string) error {
+	if len(name) < MinCharacterNameLength || len(name) > MaxCharacterNameLength {
+		return ErrCharacterNameLength
+	}
+
+	separators := 0
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case c == '-' || c == '_':
+			separators++
+			if separators > 1 || i == 0 || i == len(name)-1 {
+				return ErrCharacterNameCharacters
+			}
+		default:
+			return ErrCharacterNameCharacters
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package mcp_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp"
+	"github.com/samlitowitz/bnet-mcp/pkg/mcp/client"
+)
+
+func TestValidateCharacterName(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+	}{
+		{"Xe", nil},
+		{"Conan", nil},
+		{"Xe-na", nil},
+		{"Xe_na", nil},
+		{"abcdefghijklmno", nil},
+		{"", mcp.ErrCharacterNameLength},
+		{"X", mcp.ErrCharacterNameLength},
+		{"abcdefghijklmnop", mcp.ErrCharacterNameLength},
+		{"Conan2", mcp.ErrCharacterNameCharacters},
+		{"Co nan", mcp.ErrCharacterNameCharacters},
+		{"-Conan", mcp.ErrCharacterNameCharacters},
+		{"Conan_", mcp.ErrCharacterNameCharacters},
+		{"Xe-n_a", mcp.ErrCharacterNameCharacters},
+		{"Xe--na", mcp.ErrCharacterNameCharacters},
+	} {
+		if err := mcp.ValidateCharacterName(tt.name); err != tt.err {
+			t.Errorf("%q: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+// invalidCharCreate fails validation on its Name field.
+var invalidCharCreate = client.CharCreate{Class: mcp.ClassAmazon, Name: "C"}
+
+func wantNameError(t *testing.T, err error) {
+	t.Helper()
+	e, ok := err.(*mcp.ValidationError)
+	if !ok {
+		t.Fatalf("got %v, want *ValidationError", err)
+	}
+	if e.MessageID != mcp.McpCharCreate || e.Field != "Name" || e.Err != mcp.ErrCharacterNameLength {
+		t.Errorf("got %+v", e)
+	}
+}
+
+func TestWriterValidate(t *testing.T) {
+	var buf bytes.Buffer
+	w := mcp.NewWriter(&buf)
+	w.Validate = true
+
+	wantNameError(t, w.WriteMessage(invalidCharCreate))
+	if buf.Len() != 0 {
+		t.Errorf("wrote % x", buf.Bytes())
+	}
+
+	w.Validate = false
+	if err := w.WriteMessage(invalidCharCreate); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() == 0 {
+		t.Error("wrote nothing without Validate")
+	}
+}
+
+func TestReaderValidate(t *testing.T) {
+	frame, err := mcp.Append(nil, mcp.McpCharCreate, invalidCharCreate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := mcp.NewReader(bytes.NewReader(frame))
+	r.Validate = true
+	msg, err := r.ReadMessage(mcp.ClientToServer)
+	if msg != nil {
+		t.Errorf("returned %#v", msg)
+	}
+	wantNameError(t, err)
+
+	r = mcp.NewReader(bytes.NewReader(frame))
+	if _, err := r.ReadMessage(mcp.ClientToServer); err != nil {
+		t.Errorf("without Validate: %s", err)
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/samlitowitz/bnet-bncs
+
+go 1.12
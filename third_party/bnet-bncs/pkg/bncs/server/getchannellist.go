@@ -0,0 +1,6 @@
+package server
+
+// GetChannelListResponse is the structure of a SID_GETCHANNELLIST response
+type GetChannelList struct {
+	Channels []string
+}
@@ -0,0 +1,6 @@
+package server
+
+// PingResponse is the structure of a SID_PING response
+type Ping struct {
+	PingValue uint32
+}